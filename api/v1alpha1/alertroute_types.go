@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MatchOperator compares the value of an alert label, like the =, !=, =~ and !~
+// operators of Alertmanager matchers.
+// +kubebuilder:validation:Enum=Equal;NotEqual;Regexp;NotRegexp
+type MatchOperator string
+
+const (
+	// MatchEqual matches labels equal to the value.
+	MatchEqual MatchOperator = "Equal"
+	// MatchNotEqual matches labels not equal to the value.
+	MatchNotEqual MatchOperator = "NotEqual"
+	// MatchRegexp matches labels fully matching the regular expression.
+	MatchRegexp MatchOperator = "Regexp"
+	// MatchNotRegexp matches labels not fully matching the regular expression.
+	MatchNotRegexp MatchOperator = "NotRegexp"
+)
+
+// AlertFingerprintLabel on a GithubIssue holds the fingerprint of the alert group it was opened for.
+const AlertFingerprintLabel = "dana.io/alert-fingerprint"
+
+// AlertMatcher matches a label of alert groups, as Alertmanager matchers do.
+// Missing labels have the empty value.
+type AlertMatcher struct {
+	// Label is the name of the alert label.
+	// +kubebuilder:validation:MinLength=1
+	Label string `json:"label"`
+
+	// Operator compares the label with value.
+	// +optional
+	// +kubebuilder:default=Equal
+	Operator MatchOperator `json:"operator,omitempty"`
+
+	// Value is the value, or the regular expression, the label is compared with.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// AlertRouteSpec defines the desired state of AlertRoute.
+// +kubebuilder:validation:XValidation:rule="has(self.repo) != has(self.repositoryRef)",message="exactly one of repo and repositoryRef must be set"
+// +kubebuilder:validation:XValidation:rule="[has(self.tokenSecretRef), has(self.appSecretRef), has(self.credentialsRef)].filter(x, x).size() <= 1",message="tokenSecretRef, appSecretRef and credentialsRef are mutually exclusive"
+type AlertRouteSpec struct {
+	// Matchers select the alert groups routed to the repository by the
+	// labels common to their alerts. A route without matchers routes every
+	// alert group.
+	// +optional
+	Matchers []AlertMatcher `json:"matchers,omitempty"`
+
+	// Priority orders the routes matching an alert group; the route with the
+	// highest priority wins, and ties go to the first in name order.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	Priority int32 `json:"priority,omitempty"`
+
+	// IssueNamespace is the namespace the GithubIssues of the routed alert
+	// groups are kept in. The repository and credentials references are
+	// resolved in it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	IssueNamespace string `json:"issueNamespace"`
+
+	// Repo is the repository issues are opened in, in "owner/name" form.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`
+	Repo string `json:"repo,omitempty"`
+
+	// RepositoryRef references the GithubRepository issues are opened in.
+	// +optional
+	RepositoryRef *LocalRepositoryReference `json:"repositoryRef,omitempty"`
+
+	// TokenSecretRef references a Secret holding the GitHub token of the issues.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret holding the GitHub App credentials of the issues.
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// CredentialsRef references the GithubCredentials of the issues.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+
+	// Labels are the names of the labels applied to the issues.
+	// +optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+
+	// Assignees are the GitHub logins the issues are assigned to.
+	// +optional
+	// +listType=set
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Issue Namespace",type=string,JSONPath=`.spec.issueNamespace`
+// +kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.spec.repo`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AlertRoute is the Schema for the alertroutes API. It routes the alert
+// groups Alertmanager notifies the operator of to a repository: each group
+// becomes a GithubIssue in the issue namespace of the route, which is closed
+// once the group is resolved. AlertRoutes are cluster-scoped, as they receive
+// the alerts of the whole cluster.
+type AlertRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertRouteSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertRouteList contains a list of AlertRoute.
+type AlertRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertRoute `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertRoute{}, &AlertRouteList{})
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GithubCredentialsSpec defines the desired state of GithubCredentials.
+// +kubebuilder:validation:XValidation:rule="has(self.tokenSecretRef) != has(self.appSecretRef)",message="exactly one of tokenSecretRef and appSecretRef must be set"
+type GithubCredentialsSpec struct {
+	// TokenSecretRef references the Secret in the operator namespace holding a
+	// GitHub token.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret in the operator namespace holding GitHub
+	// App credentials under the keys "app-id", "installation-id" and
+	// "private-key".
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// AllowedNamespaces selects the namespaces whose objects may use these
+	// credentials. An empty selector allows all namespaces; when it is not set
+	// no namespace may use them.
+	// +optional
+	AllowedNamespaces *metav1.LabelSelector `json:"allowedNamespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GithubCredentials is the Schema for the githubcredentials API. It shares
+// GitHub credentials stored in the operator namespace with other namespaces.
+type GithubCredentials struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GithubCredentialsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GithubCredentialsList contains a list of GithubCredentials.
+type GithubCredentialsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GithubCredentials `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GithubCredentials{}, &GithubCredentialsList{})
+}
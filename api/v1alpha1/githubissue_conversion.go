@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/TalDebi/GithubIssue.git/api/v1beta1"
+)
+
+// ConvertTo converts this GithubIssue to the Hub version (v1beta1).
+func (src *GithubIssue) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.GithubIssue)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = v1beta1.GithubIssueSpec{
+		Repo:                src.Spec.Repo,
+		IssueNumber:         src.Spec.IssueNumber,
+		AdoptExisting:       src.Spec.AdoptExisting,
+		Title:               src.Spec.Title,
+		Description:         src.Spec.Description,
+		Labels:              src.Spec.Labels,
+		CreateMissingLabels: src.Spec.CreateMissingLabels,
+		Assignees:           src.Spec.Assignees,
+		Milestone:           src.Spec.Milestone,
+		MilestoneTitle:      src.Spec.MilestoneTitle,
+		TemplateData:        src.Spec.TemplateData,
+		State:               v1beta1.IssueState(src.Spec.State),
+		SyncPolicy:          v1beta1.SyncPolicy(src.Spec.SyncPolicy),
+		DeletionPolicy:      v1beta1.DeletionPolicy(src.Spec.DeletionPolicy),
+	}
+	if ref := src.Spec.RepositoryRef; ref != nil {
+		dst.Spec.RepositoryRef = &v1beta1.LocalRepositoryReference{Name: ref.Name}
+	}
+	if tmpl := src.Spec.BodyTemplate; tmpl != nil {
+		dst.Spec.BodyTemplate = &v1beta1.BodyTemplate{Inline: tmpl.Inline}
+		if ref := tmpl.ConfigMapRef; ref != nil {
+			dst.Spec.BodyTemplate.ConfigMapRef = &v1beta1.ConfigMapKeyReference{Name: ref.Name, Key: ref.Key}
+		}
+	}
+	if ref := src.Spec.TokenSecretRef; ref != nil {
+		dst.Spec.TokenSecretRef = &v1beta1.SecretKeyReference{Name: ref.Name, Key: ref.Key}
+	}
+	if ref := src.Spec.AppSecretRef; ref != nil {
+		dst.Spec.AppSecretRef = &v1beta1.LocalSecretReference{Name: ref.Name}
+	}
+	if ref := src.Spec.CredentialsRef; ref != nil {
+		dst.Spec.CredentialsRef = &v1beta1.LocalCredentialsReference{Name: ref.Name}
+	}
+
+	dst.Status = v1beta1.GithubIssueStatus{
+		Repo:               src.Status.Repo,
+		Number:             src.Status.Number,
+		URL:                src.Status.URL,
+		NodeID:             src.Status.NodeID,
+		State:              v1beta1.IssueState(src.Status.State),
+		LastSyncedAt:       src.Status.LastSyncedAt,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+	}
+	if src.Status.Drift != nil {
+		dst.Status.Drift = make([]v1beta1.FieldDrift, len(src.Status.Drift))
+		for i, drift := range src.Status.Drift {
+			dst.Status.Drift[i] = v1beta1.FieldDrift(drift)
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *GithubIssue) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.GithubIssue)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = GithubIssueSpec{
+		Repo:                src.Spec.Repo,
+		IssueNumber:         src.Spec.IssueNumber,
+		AdoptExisting:       src.Spec.AdoptExisting,
+		Title:               src.Spec.Title,
+		Description:         src.Spec.Description,
+		Labels:              src.Spec.Labels,
+		CreateMissingLabels: src.Spec.CreateMissingLabels,
+		Assignees:           src.Spec.Assignees,
+		Milestone:           src.Spec.Milestone,
+		MilestoneTitle:      src.Spec.MilestoneTitle,
+		TemplateData:        src.Spec.TemplateData,
+		State:               IssueState(src.Spec.State),
+		SyncPolicy:          SyncPolicy(src.Spec.SyncPolicy),
+		DeletionPolicy:      DeletionPolicy(src.Spec.DeletionPolicy),
+	}
+	if ref := src.Spec.RepositoryRef; ref != nil {
+		dst.Spec.RepositoryRef = &LocalRepositoryReference{Name: ref.Name}
+	}
+	if tmpl := src.Spec.BodyTemplate; tmpl != nil {
+		dst.Spec.BodyTemplate = &BodyTemplate{Inline: tmpl.Inline}
+		if ref := tmpl.ConfigMapRef; ref != nil {
+			dst.Spec.BodyTemplate.ConfigMapRef = &ConfigMapKeyReference{Name: ref.Name, Key: ref.Key}
+		}
+	}
+	if ref := src.Spec.TokenSecretRef; ref != nil {
+		dst.Spec.TokenSecretRef = &SecretKeyReference{Name: ref.Name, Key: ref.Key}
+	}
+	if ref := src.Spec.AppSecretRef; ref != nil {
+		dst.Spec.AppSecretRef = &LocalSecretReference{Name: ref.Name}
+	}
+	if ref := src.Spec.CredentialsRef; ref != nil {
+		dst.Spec.CredentialsRef = &LocalCredentialsReference{Name: ref.Name}
+	}
+
+	dst.Status = GithubIssueStatus{
+		Repo:               src.Status.Repo,
+		Number:             src.Status.Number,
+		URL:                src.Status.URL,
+		NodeID:             src.Status.NodeID,
+		State:              IssueState(src.Status.State),
+		LastSyncedAt:       src.Status.LastSyncedAt,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+	}
+	if src.Status.Drift != nil {
+		dst.Status.Drift = make([]FieldDrift, len(src.Status.Drift))
+		for i, drift := range src.Status.Drift {
+			dst.Status.Drift[i] = FieldDrift(drift)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"math/rand"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/api/v1beta1"
+)
+
+var _ = Describe("GithubIssue conversion", func() {
+	// iterations is the number of fuzzed objects each round trip is checked with.
+	const iterations = 1000
+
+	var f *fuzz.Fuzzer
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+		f = fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(GinkgoRandomSeed()), serializer.NewCodecFactory(scheme))
+	})
+
+	It("should round-trip v1alpha1 through the hub without losing data", func() {
+		for range iterations {
+			spoke := &v1alpha1.GithubIssue{}
+			f.Fuzz(spoke)
+			// The type meta is set by the conversion webhook, not by the conversion functions.
+			spoke.TypeMeta = metav1.TypeMeta{}
+
+			hub := &v1beta1.GithubIssue{}
+			Expect(spoke.DeepCopy().ConvertTo(hub)).To(Succeed())
+			restored := &v1alpha1.GithubIssue{}
+			Expect(restored.ConvertFrom(hub)).To(Succeed())
+
+			Expect(restored).To(Equal(spoke))
+		}
+	})
+
+	It("should round-trip the hub through v1alpha1 without losing data", func() {
+		for range iterations {
+			hub := &v1beta1.GithubIssue{}
+			f.Fuzz(hub)
+			hub.TypeMeta = metav1.TypeMeta{}
+
+			spoke := &v1alpha1.GithubIssue{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+			restored := &v1beta1.GithubIssue{}
+			Expect(spoke.ConvertTo(restored)).To(Succeed())
+
+			Expect(restored).To(Equal(hub))
+		}
+	})
+})
@@ -20,26 +20,306 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
-// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// IssueState is the open/closed state of a GitHub issue.
+// +kubebuilder:validation:Enum=open;closed
+type IssueState string
+
+const (
+	// IssueStateOpen marks an issue that is open on GitHub.
+	IssueStateOpen IssueState = "open"
+	// IssueStateClosed marks an issue that is closed on GitHub.
+	IssueStateClosed IssueState = "closed"
+)
+
+// DeletionPolicy controls what happens to the GitHub issue when its GithubIssue is deleted.
+// +kubebuilder:validation:Enum=Close;Lock;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyClose closes the issue on GitHub.
+	DeletionPolicyClose DeletionPolicy = "Close"
+	// DeletionPolicyLock closes the issue and locks its conversation.
+	DeletionPolicyLock DeletionPolicy = "Lock"
+	// DeletionPolicyOrphan leaves the issue on GitHub untouched.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// SyncPolicy controls how the operator treats edits made to the issue on GitHub.
+// +kubebuilder:validation:Enum=Enforce;Observe;CreateOnly
+type SyncPolicy string
+
+const (
+	// SyncPolicyEnforce reverts upstream edits so the issue matches the spec.
+	SyncPolicyEnforce SyncPolicy = "Enforce"
+	// SyncPolicyObserve reports upstream edits as drift without changing the issue.
+	SyncPolicyObserve SyncPolicy = "Observe"
+	// SyncPolicyCreateOnly creates the issue and never looks at it again.
+	SyncPolicyCreateOnly SyncPolicy = "CreateOnly"
+)
+
+// SecretKeyReference selects a key of a Secret in the namespace of the referencing object.
+type SecretKeyReference struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the key in the Secret holding the value.
+	// +optional
+	// +kubebuilder:default=token
+	Key string `json:"key,omitempty"`
+}
+
+// LocalSecretReference references a Secret in the namespace of the referencing object.
+type LocalSecretReference struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// LocalCredentialsReference references a cluster-scoped GithubCredentials object.
+type LocalCredentialsReference struct {
+	// Name is the name of the GithubCredentials.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// LocalRepositoryReference references a GithubRepository in the namespace of the referencing object.
+type LocalRepositoryReference struct {
+	// Name is the name of the GithubRepository.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ConfigMapKeyReference selects a key of a ConfigMap in the namespace of the referencing object.
+type ConfigMapKeyReference struct {
+	// Name is the name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the key in the ConfigMap holding the value.
+	// +optional
+	// +kubebuilder:default=template
+	Key string `json:"key,omitempty"`
+}
+
+// BodyTemplate is a Go text/template rendering the body of an issue. The
+// template is executed with .Name, .Namespace, .Title, .Labels and .Values,
+// the templateData of the spec.
+// +kubebuilder:validation:XValidation:rule="has(self.inline) != has(self.configMapRef)",message="exactly one of inline and configMapRef must be set"
+type BodyTemplate struct {
+	// Inline is the template itself.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapRef selects the key of a ConfigMap holding the template. The
+	// issue is rendered again when the ConfigMap changes.
+	// +optional
+	ConfigMapRef *ConfigMapKeyReference `json:"configMapRef,omitempty"`
+}
 
 // GithubIssueSpec defines the desired state of GithubIssue.
+// +kubebuilder:validation:XValidation:rule="has(self.repo) != has(self.repositoryRef)",message="exactly one of repo and repositoryRef must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.issueNumber) && has(self.adoptExisting) && self.adoptExisting)",message="issueNumber and adoptExisting are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="[has(self.tokenSecretRef), has(self.appSecretRef), has(self.credentialsRef)].filter(x, x).size() <= 1",message="tokenSecretRef, appSecretRef and credentialsRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.milestone) && has(self.milestoneTitle))",message="milestone and milestoneTitle are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.description) && has(self.bodyTemplate))",message="description and bodyTemplate are mutually exclusive"
 type GithubIssueSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Repo is the target repository in "owner/name" form.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`
+	Repo string `json:"repo,omitempty"`
+
+	// RepositoryRef references the GithubRepository the issue belongs to. Its
+	// credentials are used unless the issue references its own, and its
+	// default labels, assignees and body footer are merged into the issue.
+	// +optional
+	RepositoryRef *LocalRepositoryReference `json:"repositoryRef,omitempty"`
+
+	// IssueNumber binds this object to the existing issue with that number
+	// instead of creating one. The issue is adopted as is and patched to match
+	// the spec from then on.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IssueNumber *int `json:"issueNumber,omitempty"`
+
+	// AdoptExisting binds this object to an existing issue with the same title
+	// when there is one, instead of always creating a new issue.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// Title is the title of the issue.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Title string `json:"title"`
+
+	// Description is the markdown body of the issue.
+	// +optional
+	Description string `json:"description,omitempty"`
 
-	// Foo is an example field of GithubIssue. Edit githubissue_types.go to remove/update
-	Foo string `json:"foo,omitempty"`
+	// BodyTemplate renders the markdown body of the issue instead of
+	// description.
+	// +optional
+	BodyTemplate *BodyTemplate `json:"bodyTemplate,omitempty"`
+
+	// TemplateData are the values available to bodyTemplate as .Values.
+	// +optional
+	TemplateData map[string]string `json:"templateData,omitempty"`
+
+	// Labels are the names of the labels applied to the issue.
+	// +optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+
+	// CreateMissingLabels creates the labels of the issue that do not exist in
+	// the repository yet, with the default color. Labels declared by a
+	// GithubLabel in the namespace are left to it.
+	// +optional
+	CreateMissingLabels bool `json:"createMissingLabels,omitempty"`
+
+	// Assignees are the GitHub logins the issue is assigned to.
+	// +optional
+	// +listType=set
+	Assignees []string `json:"assignees,omitempty"`
+
+	// Milestone is the number of the milestone the issue belongs to.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Milestone *int `json:"milestone,omitempty"`
+
+	// MilestoneTitle is the title of the milestone the issue belongs to. It is
+	// resolved to the number of the open or closed milestone of that title.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	MilestoneTitle string `json:"milestoneTitle,omitempty"`
+
+	// State is the desired state of the issue.
+	// +optional
+	// +kubebuilder:default=open
+	State IssueState `json:"state,omitempty"`
+
+	// SyncPolicy controls what happens when the issue is edited on GitHub.
+	// +optional
+	// +kubebuilder:default=Enforce
+	SyncPolicy SyncPolicy `json:"syncPolicy,omitempty"`
+
+	// DeletionPolicy controls what happens to the issue when this object is deleted.
+	// +optional
+	// +kubebuilder:default=Close
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TokenSecretRef references the Secret holding the GitHub token used for
+	// this issue. The operator-wide token is used when it is not set.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret holding GitHub App credentials under the
+	// keys "app-id", "installation-id" and "private-key". The operator
+	// authenticates as that app installation.
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// CredentialsRef references cluster-scoped GithubCredentials shared with
+	// this namespace.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+}
+
+// Condition types reported on a GithubIssue.
+const (
+	// ConditionReady is true when the upstream issue matches the spec.
+	ConditionReady = "Ready"
+	// ConditionSynced is true when the last sync with GitHub succeeded.
+	ConditionSynced = "Synced"
+	// ConditionDegraded is true when the operator cannot sync the issue.
+	ConditionDegraded = "Degraded"
+	// ConditionAuthFailed is true when the operator cannot authenticate against GitHub.
+	ConditionAuthFailed = "AuthFailed"
+	// ConditionForbidden is true when the namespace may not use the referenced GithubCredentials.
+	ConditionForbidden = "Forbidden"
+	// ConditionDrifted is true when the issue on GitHub differs from the spec.
+	ConditionDrifted = "Drifted"
+	// ConditionAdoptionRefused is true when the existing issue requested by the spec cannot be adopted.
+	ConditionAdoptionRefused = "AdoptionRefused"
+	// ConditionMilestoneNotFound is true when the repository has no milestone with the title requested by the spec.
+	ConditionMilestoneNotFound = "MilestoneNotFound"
+	// ConditionTemplateError is true when the body template of the spec cannot be rendered.
+	ConditionTemplateError = "TemplateError"
+)
+
+const (
+	// DefaultRepoAnnotation on a namespace names the repository, in "owner/name"
+	// form, of the GithubIssues in it that set neither repo nor repositoryRef.
+	DefaultRepoAnnotation = "dana.io/default-repo"
+	// ManagedIssueLabel is applied to every issue created from a GithubIssue.
+	ManagedIssueLabel = "managed-by-k8s"
+)
+
+// FieldDrift is a field of the issue on GitHub that differs from the spec.
+type FieldDrift struct {
+	// Field is the drifted field: title, body, labels, assignees, milestone or state.
+	Field string `json:"field"`
+
+	// Desired is the value requested by the spec.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Actual is the value found on GitHub.
+	// +optional
+	Actual string `json:"actual,omitempty"`
 }
 
 // GithubIssueStatus defines the observed state of GithubIssue.
 type GithubIssueStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Repo is the repository the issue lives in, in "owner/name" form.
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// Number is the number of the issue in its repository.
+	// +optional
+	Number int `json:"number,omitempty"`
+
+	// URL is the html URL of the issue.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// NodeID is the GraphQL node ID of the issue.
+	// +optional
+	NodeID string `json:"nodeID,omitempty"`
+
+	// State is the observed state of the issue on GitHub.
+	// +optional
+	State IssueState `json:"state,omitempty"`
+
+	// LastSyncedAt is the last time the issue was synced with GitHub.
+	// +optional
+	LastSyncedAt *metav1.Time `json:"lastSyncedAt,omitempty"`
+
+	// Drift lists the fields of the issue on GitHub that differ from the spec
+	// and were left alone because of the sync policy.
+	// +optional
+	// +listType=map
+	// +listMapKey=field
+	Drift []FieldDrift `json:"drift,omitempty"`
+
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the issue.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.status.repo`
+// +kubebuilder:printcolumn:name="Number",type=integer,JSONPath=`.status.number`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // GithubIssue is the Schema for the githubissues API.
 type GithubIssue struct {
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocalIssueReference references a GithubIssue in the namespace of the referencing object.
+type LocalIssueReference struct {
+	// Name is the name of the GithubIssue.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// GithubIssueCommentSpec defines the desired state of GithubIssueComment.
+type GithubIssueCommentSpec struct {
+	// IssueRef references the GithubIssue the comment is posted on.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="issueRef is immutable"
+	IssueRef LocalIssueReference `json:"issueRef"`
+
+	// Body is the markdown body of the comment. The comment is edited on
+	// GitHub whenever it changes.
+	// +kubebuilder:validation:MinLength=1
+	Body string `json:"body"`
+}
+
+// GithubIssueCommentStatus defines the observed state of GithubIssueComment.
+type GithubIssueCommentStatus struct {
+	// Repo is the repository of the issue the comment is posted on.
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// IssueNumber is the number of the issue the comment is posted on.
+	// +optional
+	IssueNumber int `json:"issueNumber,omitempty"`
+
+	// CommentID is the ID of the comment on GitHub.
+	// +optional
+	CommentID int64 `json:"commentID,omitempty"`
+
+	// URL is the html URL of the comment.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the comment.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Issue",type=string,JSONPath=`.spec.issueRef.name`
+// +kubebuilder:printcolumn:name="Comment",type=integer,JSONPath=`.status.commentID`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GithubIssueComment is the Schema for the githubissuecomments API. It is
+// reconciled into a single comment on the issue of the referenced GithubIssue.
+type GithubIssueComment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GithubIssueCommentSpec   `json:"spec,omitempty"`
+	Status GithubIssueCommentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GithubIssueCommentList contains a list of GithubIssueComment.
+type GithubIssueCommentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GithubIssueComment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GithubIssueComment{}, &GithubIssueCommentList{})
+}
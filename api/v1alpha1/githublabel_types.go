@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelDeletionPolicy controls what happens to the GitHub label when its GithubLabel is deleted.
+// +kubebuilder:validation:Enum=Delete;Orphan
+type LabelDeletionPolicy string
+
+const (
+	// LabelDeletionPolicyDelete deletes the label from the repository and from all its issues.
+	LabelDeletionPolicyDelete LabelDeletionPolicy = "Delete"
+	// LabelDeletionPolicyOrphan leaves the label on GitHub untouched.
+	LabelDeletionPolicyOrphan LabelDeletionPolicy = "Orphan"
+)
+
+// DefaultLabelColor is the color of labels that do not ask for one.
+const DefaultLabelColor = "ededed"
+
+// GithubLabelSpec defines the desired state of GithubLabel.
+// +kubebuilder:validation:XValidation:rule="has(self.repo) != has(self.repositoryRef)",message="exactly one of repo and repositoryRef must be set"
+// +kubebuilder:validation:XValidation:rule="[has(self.tokenSecretRef), has(self.appSecretRef), has(self.credentialsRef)].filter(x, x).size() <= 1",message="tokenSecretRef, appSecretRef and credentialsRef are mutually exclusive"
+type GithubLabelSpec struct {
+	// Repo is the repository the label belongs to, in "owner/name" form.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`
+	Repo string `json:"repo,omitempty"`
+
+	// RepositoryRef references the GithubRepository the label belongs to. Its
+	// credentials are used unless the label references its own.
+	// +optional
+	RepositoryRef *LocalRepositoryReference `json:"repositoryRef,omitempty"`
+
+	// Name is the name of the label. Renaming it renames the label on GitHub,
+	// keeping it on the issues it is applied to.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+
+	// Color is the hex color code of the label, without the leading "#".
+	// +optional
+	// +kubebuilder:default=ededed
+	// +kubebuilder:validation:Pattern=`^[0-9A-Fa-f]{6}$`
+	Color string `json:"color,omitempty"`
+
+	// Description is a short description of the label.
+	// +optional
+	// +kubebuilder:validation:MaxLength=100
+	Description string `json:"description,omitempty"`
+
+	// Adopt takes over a label of the same name that already exists on GitHub.
+	// Without it such a label is reported through the Conflict condition and
+	// left untouched.
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
+
+	// DeletionPolicy controls what happens to the label when this object is deleted.
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy LabelDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TokenSecretRef references the Secret holding the GitHub token used for
+	// this label. The operator-wide token is used when it is not set.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret holding GitHub App credentials under the
+	// keys "app-id", "installation-id" and "private-key".
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// CredentialsRef references cluster-scoped GithubCredentials shared with
+	// this namespace.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+}
+
+// ConditionConflict is true when a label or milestone of the same name exists
+// on GitHub that the GithubLabel or GithubMilestone neither created nor adopted.
+const ConditionConflict = "Conflict"
+
+// GithubLabelStatus defines the observed state of GithubLabel.
+type GithubLabelStatus struct {
+	// Repo is the repository holding the managed label, in "owner/name" form.
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// Name is the name of the managed label on GitHub. It is set once the
+	// label was created or adopted, and marks the label as owned by this object.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the label.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.status.repo`
+// +kubebuilder:printcolumn:name="Label",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Color",type=string,JSONPath=`.spec.color`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GithubLabel is the Schema for the githublabels API. It is reconciled into a
+// label of a GitHub repository.
+type GithubLabel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GithubLabelSpec   `json:"spec,omitempty"`
+	Status GithubLabelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GithubLabelList contains a list of GithubLabel.
+type GithubLabelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GithubLabel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GithubLabel{}, &GithubLabelList{})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MilestoneState is the open/closed state of a GitHub milestone.
+// +kubebuilder:validation:Enum=open;closed
+type MilestoneState string
+
+const (
+	// MilestoneStateOpen marks a milestone that is open on GitHub.
+	MilestoneStateOpen MilestoneState = "open"
+	// MilestoneStateClosed marks a milestone that is closed on GitHub.
+	MilestoneStateClosed MilestoneState = "closed"
+)
+
+// MilestoneDeletionPolicy controls what happens to the GitHub milestone when its GithubMilestone is deleted.
+// +kubebuilder:validation:Enum=Close;Delete;Orphan
+type MilestoneDeletionPolicy string
+
+const (
+	// MilestoneDeletionPolicyClose closes the milestone on GitHub.
+	MilestoneDeletionPolicyClose MilestoneDeletionPolicy = "Close"
+	// MilestoneDeletionPolicyDelete deletes the milestone, removing it from its issues.
+	MilestoneDeletionPolicyDelete MilestoneDeletionPolicy = "Delete"
+	// MilestoneDeletionPolicyOrphan leaves the milestone on GitHub untouched.
+	MilestoneDeletionPolicyOrphan MilestoneDeletionPolicy = "Orphan"
+)
+
+// GithubMilestoneSpec defines the desired state of GithubMilestone.
+// +kubebuilder:validation:XValidation:rule="has(self.repo) != has(self.repositoryRef)",message="exactly one of repo and repositoryRef must be set"
+// +kubebuilder:validation:XValidation:rule="[has(self.tokenSecretRef), has(self.appSecretRef), has(self.credentialsRef)].filter(x, x).size() <= 1",message="tokenSecretRef, appSecretRef and credentialsRef are mutually exclusive"
+type GithubMilestoneSpec struct {
+	// Repo is the repository the milestone belongs to, in "owner/name" form.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`
+	Repo string `json:"repo,omitempty"`
+
+	// RepositoryRef references the GithubRepository the milestone belongs to.
+	// Its credentials are used unless the milestone references its own.
+	// +optional
+	RepositoryRef *LocalRepositoryReference `json:"repositoryRef,omitempty"`
+
+	// Title is the title of the milestone. GithubIssues refer to the milestone
+	// by it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Title string `json:"title"`
+
+	// Description is the markdown description of the milestone.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// DueOn is the due date of the milestone. GitHub only keeps its date.
+	// +optional
+	DueOn *metav1.Time `json:"dueOn,omitempty"`
+
+	// State is the desired state of the milestone. Closing it closes the
+	// milestone on GitHub.
+	// +optional
+	// +kubebuilder:default=open
+	State MilestoneState `json:"state,omitempty"`
+
+	// Adopt takes over a milestone of the same title that already exists on
+	// GitHub. Without it such a milestone is reported through the Conflict
+	// condition and left untouched.
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
+
+	// DeletionPolicy controls what happens to the milestone when this object is deleted.
+	// +optional
+	// +kubebuilder:default=Close
+	DeletionPolicy MilestoneDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TokenSecretRef references the Secret holding the GitHub token used for
+	// this milestone. The operator-wide token is used when it is not set.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret holding GitHub App credentials under the
+	// keys "app-id", "installation-id" and "private-key".
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// CredentialsRef references cluster-scoped GithubCredentials shared with
+	// this namespace.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+}
+
+// GithubMilestoneStatus defines the observed state of GithubMilestone.
+type GithubMilestoneStatus struct {
+	// Repo is the repository holding the managed milestone, in "owner/name" form.
+	// +optional
+	Repo string `json:"repo,omitempty"`
+
+	// Number is the number of the managed milestone in its repository. It is
+	// set once the milestone was created or adopted, and marks the milestone
+	// as owned by this object.
+	// +optional
+	Number int `json:"number,omitempty"`
+
+	// URL is the html URL of the milestone.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// State is the observed state of the milestone on GitHub.
+	// +optional
+	State MilestoneState `json:"state,omitempty"`
+
+	// ObservedGeneration is the generation last processed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the milestone.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.status.repo`
+// +kubebuilder:printcolumn:name="Title",type=string,JSONPath=`.spec.title`
+// +kubebuilder:printcolumn:name="Number",type=integer,JSONPath=`.status.number`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GithubMilestone is the Schema for the githubmilestones API. It is reconciled
+// into a milestone of a GitHub repository.
+type GithubMilestone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GithubMilestoneSpec   `json:"spec,omitempty"`
+	Status GithubMilestoneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GithubMilestoneList contains a list of GithubMilestone.
+type GithubMilestoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GithubMilestone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GithubMilestone{}, &GithubMilestoneList{})
+}
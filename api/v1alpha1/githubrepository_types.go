@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GithubRepositorySpec defines the desired state of GithubRepository.
+// +kubebuilder:validation:XValidation:rule="[has(self.tokenSecretRef), has(self.appSecretRef), has(self.credentialsRef)].filter(x, x).size() <= 1",message="tokenSecretRef, appSecretRef and credentialsRef are mutually exclusive"
+type GithubRepositorySpec struct {
+	// Owner is the user or organization owning the repository.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*$`
+	Owner string `json:"owner"`
+
+	// Name is the name of the repository.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	Name string `json:"name"`
+
+	// TokenSecretRef references the Secret holding the GitHub token used for
+	// the issues of this repository.
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// AppSecretRef references a Secret holding GitHub App credentials under the
+	// keys "app-id", "installation-id" and "private-key".
+	// +optional
+	AppSecretRef *LocalSecretReference `json:"appSecretRef,omitempty"`
+
+	// CredentialsRef references cluster-scoped GithubCredentials shared with
+	// this namespace.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+
+	// DefaultLabels are added to the labels of every issue of the repository.
+	// +optional
+	// +listType=set
+	DefaultLabels []string `json:"defaultLabels,omitempty"`
+
+	// DefaultAssignees are assigned to issues of the repository that do not
+	// list assignees of their own.
+	// +optional
+	// +listType=set
+	DefaultAssignees []string `json:"defaultAssignees,omitempty"`
+
+	// BodyFooter is markdown appended to the description of every issue of
+	// the repository.
+	// +optional
+	BodyFooter string `json:"bodyFooter,omitempty"`
+}
+
+// FullName returns the repository in "owner/name" form.
+func (s GithubRepositorySpec) FullName() string {
+	return s.Owner + "/" + s.Name
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Owner",type=string,JSONPath=`.spec.owner`
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GithubRepository is the Schema for the githubrepositories API. It holds
+// the defaults shared by the GithubIssues of one repository.
+type GithubRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GithubRepositorySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GithubRepositoryList contains a list of GithubRepository.
+type GithubRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GithubRepository `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GithubRepository{}, &GithubRepositoryList{})
+}
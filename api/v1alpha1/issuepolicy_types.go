@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IssuePolicyAnnotation on a GithubIssue names the IssuePolicy it was filed by.
+	IssuePolicyAnnotation = "dana.io/issue-policy"
+	// PodAnnotation on a GithubIssue names the pod it was filed for.
+	PodAnnotation = "dana.io/pod"
+)
+
+const (
+	// DefaultRestartThreshold is the restartThreshold of policies that set none.
+	DefaultRestartThreshold = 5
+	// DefaultLogTailLines is the logTailLines of policies that set none.
+	DefaultLogTailLines = 50
+)
+
+// IssuePolicySpec defines the desired state of IssuePolicy.
+// +kubebuilder:validation:XValidation:rule="has(self.repo) != has(self.repositoryRef)",message="exactly one of repo and repositoryRef must be set"
+type IssuePolicySpec struct {
+	// Repo is the repository issues are filed in, in "owner/name" form.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`
+	Repo string `json:"repo,omitempty"`
+
+	// RepositoryRef references the GithubRepository, in the namespace of the
+	// crashing pod, that issues are filed in.
+	// +optional
+	RepositoryRef *LocalRepositoryReference `json:"repositoryRef,omitempty"`
+
+	// CredentialsRef references the GithubCredentials the filed issues use.
+	// They must allow the namespaces selected by the policy.
+	// +optional
+	CredentialsRef *LocalCredentialsReference `json:"credentialsRef,omitempty"`
+
+	// Labels are the names of the labels applied to the filed issues.
+	// +optional
+	// +listType=set
+	Labels []string `json:"labels,omitempty"`
+
+	// Assignees are the GitHub logins the filed issues are assigned to.
+	// +optional
+	// +listType=set
+	Assignees []string `json:"assignees,omitempty"`
+
+	// RestartThreshold is how often a container in CrashLoopBackOff must have
+	// restarted before an issue is filed for it.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	RestartThreshold int32 `json:"restartThreshold,omitempty"`
+
+	// LogTailLines is how many lines of the log of the crashed container are
+	// quoted in the issue. Zero leaves the log out.
+	// +optional
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	LogTailLines *int64 `json:"logTailLines,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose pods the policy applies
+	// to. An empty selector selects all namespaces; when it is not set the
+	// policy applies to none.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector narrows the policy down to the pods it selects. All pods
+	// of the selected namespaces are selected when it is not set.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Repo",type=string,JSONPath=`.spec.repo`
+// +kubebuilder:printcolumn:name="Threshold",type=integer,JSONPath=`.spec.restartThreshold`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// IssuePolicy is the Schema for the issuepolicies API. It files a GithubIssue
+// in the namespace of every selected pod whose containers are stuck in
+// CrashLoopBackOff. The issue is filed once per workload and container; it is
+// created on GitHub and then left to humans, and deleting the GithubIssue
+// leaves the issue open and files a new one on the next crash.
+type IssuePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IssuePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IssuePolicyList contains a list of IssuePolicy.
+type IssuePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IssuePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IssuePolicy{}, &IssuePolicyList{})
+}
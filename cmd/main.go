@@ -17,18 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,7 +45,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	danaiov1beta1 "github.com/TalDebi/GithubIssue.git/api/v1beta1"
 	"github.com/TalDebi/GithubIssue.git/internal/controller"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+	"github.com/TalDebi/GithubIssue.git/internal/receiver"
+	webhookdanaiov1alpha1 "github.com/TalDebi/GithubIssue.git/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -49,6 +62,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(danaiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(danaiov1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -58,6 +72,18 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var githubAppID int64
+	var githubAppInstallationID int64
+	var githubAppPrivateKeyFile string
+	var githubAPIURL string
+	var githubCAConfigMap string
+	var operatorNamespace string
+	var githubRequestsPerHour int
+	var githubCacheSizeMB int
+	var clusterID string
+	var githubWebhookAddr string
+	var alertmanagerWebhookAddr string
+	var alertmanagerInsecure bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -69,6 +95,34 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.Int64Var(&githubAppID, "github-app-id", 0,
+		"ID of the GitHub App the operator authenticates as by default. Takes precedence over GITHUB_TOKEN.")
+	flag.Int64Var(&githubAppInstallationID, "github-app-installation-id", 0,
+		"ID of the installation of the default GitHub App.")
+	flag.StringVar(&githubAppPrivateKeyFile, "github-app-private-key-file", "",
+		"Path to the PEM encoded private key of the default GitHub App.")
+	flag.StringVar(&githubAPIURL, "github-api-url", github.DefaultBaseURL,
+		"Base URL of the GitHub REST API, e.g. https://ghe.example.com/api/v3 for GitHub Enterprise Server. "+
+			"Credentials Secrets may override it with an api-url key.")
+	flag.StringVar(&githubCAConfigMap, "github-ca-configmap", "",
+		"ConfigMap in namespace/name form whose ca.crt key holds additional CAs trusted for the GitHub API.")
+	flag.StringVar(&operatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace holding the Secrets referenced by GithubCredentials. Defaults to $POD_NAMESPACE.")
+	flag.IntVar(&githubRequestsPerHour, "github-requests-per-hour", github.DefaultRequestsPerHour,
+		"Budget of GitHub requests per hour for each credential. Zero only enforces the limits reported by GitHub.")
+	flag.IntVar(&githubCacheSizeMB, "github-cache-size-mb", github.DefaultCacheSize>>20,
+		"Megabytes of GitHub responses cached for conditional requests. Zero disables the cache.")
+	flag.StringVar(&clusterID, "cluster-id", "",
+		"Identifies this cluster in the ownership markers embedded in the body of managed issues.")
+	flag.StringVar(&githubWebhookAddr, "github-webhook-bind-address", "0",
+		"The address GitHub webhook deliveries are received on, or 0 to disable the receiver. "+
+			"Deliveries are verified with the secret in GITHUB_WEBHOOK_SECRET.")
+	flag.StringVar(&alertmanagerWebhookAddr, "alertmanager-webhook-bind-address", "0",
+		"The address Alertmanager webhook notifications are received on, or 0 to disable the receiver. "+
+			"Notifications must carry the bearer token in ALERTMANAGER_WEBHOOK_TOKEN.")
+	flag.BoolVar(&alertmanagerInsecure, "alertmanager-insecure", false,
+		"If set, Alertmanager notifications are accepted without a bearer token when "+
+			"ALERTMANAGER_WEBHOOK_TOKEN is not set. Only use it on a trusted network.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -125,6 +179,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "e27d437e.dana.io",
+		// Secrets, ConfigMaps and Pods are read from the API server instead of
+		// being cached cluster-wide.
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: controller.UncachedObjects()}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -142,13 +199,133 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := github.ValidateBaseURL(githubAPIURL); err != nil {
+		setupLog.Error(err, "invalid --github-api-url")
+		os.Exit(1)
+	}
+	var caBundle []byte
+	if githubCAConfigMap != "" {
+		if caBundle, err = readCABundle(mgr.GetAPIReader(), githubCAConfigMap); err != nil {
+			setupLog.Error(err, "unable to read GitHub CA bundle", "configmap", githubCAConfigMap)
+			os.Exit(1)
+		}
+	}
+	httpClient, err := github.NewHTTPClient(caBundle)
+	if err != nil {
+		setupLog.Error(err, "unable to load GitHub CA bundle", "configmap", githubCAConfigMap)
+		os.Exit(1)
+	}
+
+	defaultCredentials := github.Credentials{Token: os.Getenv("GITHUB_TOKEN")}
+	if githubAppID != 0 {
+		privateKey, err := os.ReadFile(githubAppPrivateKeyFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read GitHub App private key")
+			os.Exit(1)
+		}
+		defaultCredentials = github.Credentials{
+			AppID:          githubAppID,
+			InstallationID: githubAppInstallationID,
+			PrivateKey:     privateKey,
+		}
+	}
+
+	githubClients := github.NewRESTClientFactory(githubAPIURL, httpClient).
+		WithRateLimit(githubRequestsPerHour, github.DefaultBurst).
+		WithCacheSize(githubCacheSizeMB << 20)
+	var githubEvents chan event.GenericEvent
+	if githubWebhookAddr != "0" {
+		secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+		if secret == "" {
+			setupLog.Error(nil, "GITHUB_WEBHOOK_SECRET must be set when --github-webhook-bind-address is set")
+			os.Exit(1)
+		}
+		githubEvents = make(chan event.GenericEvent, 100)
+		mux := http.NewServeMux()
+		mux.Handle(receiver.GitHubPath, &receiver.GitHub{
+			Client: mgr.GetClient(),
+			Secret: []byte(secret),
+			Events: githubEvents,
+		})
+		if err := mgr.Add(&receiver.Server{BindAddress: githubWebhookAddr, Handler: mux}); err != nil {
+			setupLog.Error(err, "unable to set up GitHub webhook receiver")
+			os.Exit(1)
+		}
+	}
+	if alertmanagerWebhookAddr != "0" {
+		token := os.Getenv("ALERTMANAGER_WEBHOOK_TOKEN")
+		switch {
+		case token == "" && !alertmanagerInsecure:
+			setupLog.Error(nil, "ALERTMANAGER_WEBHOOK_TOKEN must be set when --alertmanager-webhook-bind-address is set, "+
+				"unless --alertmanager-insecure is set")
+			os.Exit(1)
+		case token == "":
+			setupLog.Info("ALERTMANAGER_WEBHOOK_TOKEN is not set, Alertmanager notifications are not authenticated")
+		}
+		mux := http.NewServeMux()
+		mux.Handle(receiver.AlertmanagerPath, &receiver.Alertmanager{
+			Client:   mgr.GetClient(),
+			Token:    token,
+			Insecure: alertmanagerInsecure,
+		})
+		if err := mgr.Add(&receiver.Server{BindAddress: alertmanagerWebhookAddr, Handler: mux}); err != nil {
+			setupLog.Error(err, "unable to set up Alertmanager webhook receiver")
+			os.Exit(1)
+		}
+	}
 	if err = (&controller.GithubIssueReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GitHub:             githubClients,
+		DefaultCredentials: defaultCredentials,
+		OperatorNamespace:  operatorNamespace,
+		ClusterID:          clusterID,
+		GitHubEvents:       githubEvents,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GithubIssue")
 		os.Exit(1)
 	}
+	if err = (&controller.GithubLabelReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GitHub:             githubClients,
+		DefaultCredentials: defaultCredentials,
+		OperatorNamespace:  operatorNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GithubLabel")
+		os.Exit(1)
+	}
+	if err = (&controller.GithubMilestoneReconciler{
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GitHub:             githubClients,
+		DefaultCredentials: defaultCredentials,
+		OperatorNamespace:  operatorNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GithubMilestone")
+		os.Exit(1)
+	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes clientset")
+		os.Exit(1)
+	}
+	if err = (&controller.PodReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Logs:     controller.ClientsetPodLogs{Interface: clientset},
+		Metadata: mgr.GetCache(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Pod")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookdanaiov1alpha1.SetupGithubIssueWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GithubIssue")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -166,3 +343,24 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// readCABundle reads the ca.crt key of the ConfigMap given in namespace/name form.
+func readCABundle(reader client.Reader, key string) ([]byte, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("%q is not in namespace/name form", key)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, configMap); err != nil {
+		return nil, err
+	}
+	caBundle, ok := configMap.Data["ca.crt"]
+	if !ok {
+		return nil, fmt.Errorf("configmap %q has no key %q", key, "ca.crt")
+	}
+	return []byte(caBundle), nil
+}
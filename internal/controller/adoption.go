@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// markerClockSkew is how long before the creation of a GithubIssue its issue
+// may appear to be created, to tolerate clock drift between the cluster and
+// GitHub.
+const markerClockSkew = 5 * time.Minute
+
+// adoptionError reports an existing issue the GithubIssue may not bind to. It
+// is surfaced through the AdoptionRefused condition.
+type adoptionError struct {
+	reason  string
+	message string
+}
+
+func (e *adoptionError) Error() string {
+	return e.message
+}
+
+// findIssue returns the upstream issue the GithubIssue is bound to, binding it
+// to an existing issue when the spec asks for adoption or an earlier reconcile
+// created one without recording it. A nil issue means a new one has to be
+// created.
+func (r *GithubIssueReconciler) findIssue(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue, spec danaiov1alpha1.GithubIssueSpec) (*github.Issue, error) {
+	// A recorded number only identifies the issue in the repository it was recorded for.
+	number, recordedRepo := githubIssue.Status.Number, githubIssue.Status.Repo
+	if recordedRepo != "" && recordedRepo != spec.Repo {
+		number = 0
+	}
+
+	switch {
+	case spec.IssueNumber != nil && *spec.IssueNumber != number:
+		if err := r.checkAdoptable(ctx, githubIssue, spec.Repo, *spec.IssueNumber); err != nil {
+			return nil, err
+		}
+		issue, err := gh.Get(ctx, spec.Repo, *spec.IssueNumber)
+		if err != nil {
+			return nil, err
+		}
+		return adopt(ctx, spec.Repo, issue)
+	case number != 0:
+		return gh.Get(ctx, spec.Repo, number)
+	case spec.AdoptExisting:
+		issue, err := findIssueByTitle(ctx, gh, spec.Repo, spec.Title)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			if err := r.checkAdoptable(ctx, githubIssue, spec.Repo, issue.Number); err != nil {
+				return nil, err
+			}
+			return adopt(ctx, spec.Repo, issue)
+		}
+	}
+	return r.findIssueByMarker(ctx, gh, githubIssue, spec.Repo)
+}
+
+// marker returns the ownership marker embedded in the body of the issue of githubIssue.
+func (r *GithubIssueReconciler) marker(githubIssue *danaiov1alpha1.GithubIssue) github.Marker {
+	return github.Marker{
+		ClusterID: r.ClusterID,
+		Namespace: githubIssue.Namespace,
+		Name:      githubIssue.Name,
+		UID:       string(githubIssue.UID),
+	}
+}
+
+// findIssueByMarker returns the issue of the repository whose body carries
+// the ownership marker of githubIssue, or nil when there is none. Only the
+// issues created since the GithubIssue are searched, as its issue cannot be
+// older.
+func (r *GithubIssueReconciler) findIssueByMarker(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue, repo string) (*github.Issue, error) {
+	opts := github.ListOptions{}
+	if !githubIssue.CreationTimestamp.IsZero() {
+		opts.CreatedSince = githubIssue.CreationTimestamp.Add(-markerClockSkew)
+	}
+	issues, err := gh.List(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	want := r.marker(githubIssue)
+	for i := range issues {
+		if marker, ok := github.ParseMarker(issues[i].Body); ok && marker.Owns(want) {
+			log.FromContext(ctx).Info("found GitHub issue created by an earlier reconcile",
+				"repo", repo, "number", issues[i].Number)
+			return &issues[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// adopt refuses pull requests, which share their numbers with issues.
+func adopt(ctx context.Context, repo string, issue *github.Issue) (*github.Issue, error) {
+	if issue.IsPullRequest() {
+		return nil, &adoptionError{reason: "NotAnIssue",
+			message: fmt.Sprintf("%s#%d is a pull request", repo, issue.Number)}
+	}
+	log.FromContext(ctx).Info("adopting existing GitHub issue", "repo", repo, "number", issue.Number)
+	return issue, nil
+}
+
+// checkAdoptable returns an adoptionError when another GithubIssue in the
+// cluster already owns the issue.
+func (r *GithubIssueReconciler) checkAdoptable(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue,
+	repo string, number int) error {
+	githubIssues := &danaiov1alpha1.GithubIssueList{}
+	if err := r.List(ctx, githubIssues); err != nil {
+		return err
+	}
+	self := client.ObjectKeyFromObject(githubIssue)
+	for _, other := range githubIssues.Items {
+		if client.ObjectKeyFromObject(&other) == self ||
+			other.Status.Number != number || !strings.EqualFold(other.Status.Repo, repo) {
+			continue
+		}
+		return &adoptionError{reason: "IssueAlreadyOwned",
+			message: fmt.Sprintf("%s#%d is already owned by GithubIssue %s/%s", repo, number, other.Namespace, other.Name)}
+	}
+	return nil
+}
+
+// findIssueByTitle returns the first issue of the repository whose title
+// matches, or nil when there is none.
+func findIssueByTitle(ctx context.Context, gh github.IssueClient, repo, title string) (*github.Issue, error) {
+	issues, err := gh.List(ctx, repo, github.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range issues {
+		if issues[i].Title == title {
+			return &issues[i], nil
+		}
+	}
+	return nil, nil
+}
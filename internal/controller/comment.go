@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// githubIssueCommentFinalizer guards the upstream comment until it has been deleted.
+const githubIssueCommentFinalizer = "dana.io/githubissuecomment-finalizer"
+
+// commentIssueRefIndex indexes GithubIssueComments by the name of the GithubIssue they are posted on.
+const commentIssueRefIndex = ".spec.issueRef.name"
+
+// syncComments reconciles the GithubIssueComments posted on githubIssue, whose
+// issue must have been synced already. Each comment records its own failure,
+// and the failures are returned together.
+func (r *GithubIssueReconciler) syncComments(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue) error {
+	comments, err := r.commentsOn(ctx, githubIssue.Namespace, githubIssue.Name)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range comments {
+		if err := r.syncComment(ctx, gh, githubIssue, &comments[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncComment makes sure the GithubIssueComment is a single comment on the
+// issue, or deletes that comment when the GithubIssueComment is being deleted.
+func (r *GithubIssueReconciler) syncComment(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue, comment *danaiov1alpha1.GithubIssueComment) error {
+	if !comment.DeletionTimestamp.IsZero() {
+		return r.deleteComment(ctx, gh, comment)
+	}
+
+	changed := controllerutil.AddFinalizer(comment, githubIssueCommentFinalizer)
+	if !metav1.IsControlledBy(comment, githubIssue) {
+		if err := controllerutil.SetControllerReference(githubIssue, comment, r.Scheme); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		if err := r.Update(ctx, comment); err != nil {
+			return err
+		}
+	}
+
+	status := comment.Status.DeepCopy()
+	posted, err := r.postComment(ctx, gh, githubIssue, comment)
+	if err != nil {
+		meta.SetStatusCondition(&comment.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionSynced,
+			Status: metav1.ConditionFalse, Reason: "SyncFailed", Message: err.Error(),
+			ObservedGeneration: comment.Generation})
+		meta.SetStatusCondition(&comment.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+			Status: metav1.ConditionFalse, Reason: "SyncFailed", Message: "comment could not be synced with GitHub",
+			ObservedGeneration: comment.Generation})
+		return errors.Join(err, r.updateCommentStatus(ctx, comment, status))
+	}
+
+	comment.Status.Repo = githubIssue.Status.Repo
+	comment.Status.IssueNumber = githubIssue.Status.Number
+	comment.Status.CommentID = posted.ID
+	comment.Status.URL = posted.HTMLURL
+	comment.Status.ObservedGeneration = comment.Generation
+	meta.SetStatusCondition(&comment.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionSynced,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "comment is in sync with GitHub",
+		ObservedGeneration: comment.Generation})
+	meta.SetStatusCondition(&comment.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "comment matches the spec",
+		ObservedGeneration: comment.Generation})
+	return r.updateCommentStatus(ctx, comment, status)
+}
+
+// updateCommentStatus writes the status of comment unless it still equals old,
+// so that reconciling an issue does not trigger itself through its comments.
+func (r *GithubIssueReconciler) updateCommentStatus(ctx context.Context, comment *danaiov1alpha1.GithubIssueComment,
+	old *danaiov1alpha1.GithubIssueCommentStatus) error {
+	if equality.Semantic.DeepEqual(old, &comment.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, comment)
+}
+
+// postComment creates the comment on the issue, or edits the existing one
+// when its body differs from the spec.
+func (r *GithubIssueReconciler) postComment(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue, comment *danaiov1alpha1.GithubIssueComment) (*github.Comment, error) {
+	repo, number := githubIssue.Status.Repo, githubIssue.Status.Number
+	body := github.WithMarker(comment.Spec.Body, r.commentMarker(comment))
+
+	existing, err := r.findComment(ctx, gh, comment, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return gh.Comment(ctx, repo, number, body)
+	}
+	if existing.Body == body {
+		return existing, nil
+	}
+	return gh.UpdateComment(ctx, repo, existing.ID, body)
+}
+
+// findComment returns the comment recorded in status, or the comment carrying
+// the marker of the GithubIssueComment when none was recorded on this issue.
+// It returns nil when the comment does not exist, e.g. because it was deleted
+// on GitHub.
+func (r *GithubIssueReconciler) findComment(ctx context.Context, gh github.IssueClient,
+	comment *danaiov1alpha1.GithubIssueComment, repo string, number int) (*github.Comment, error) {
+	if comment.Status.CommentID != 0 && comment.Status.Repo == repo && comment.Status.IssueNumber == number {
+		found, err := gh.GetComment(ctx, repo, comment.Status.CommentID)
+		if github.IsNotFound(err) {
+			return nil, nil
+		}
+		return found, err
+	}
+
+	comments, err := gh.ListComments(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	want := r.commentMarker(comment)
+	for i := range comments {
+		if marker, ok := github.ParseMarker(comments[i].Body); ok && marker.Owns(want) {
+			log.FromContext(ctx).Info("found GitHub comment created by an earlier reconcile",
+				"repo", repo, "number", number, "comment", comments[i].ID)
+			return &comments[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteComment deletes the comment from GitHub and removes the finalizer of
+// the GithubIssueComment.
+func (r *GithubIssueReconciler) deleteComment(ctx context.Context, gh github.IssueClient,
+	comment *danaiov1alpha1.GithubIssueComment) error {
+	if !controllerutil.ContainsFinalizer(comment, githubIssueCommentFinalizer) {
+		return nil
+	}
+
+	if comment.Status.CommentID != 0 {
+		err := gh.DeleteComment(ctx, comment.Status.Repo, comment.Status.CommentID)
+		if err != nil && !github.IsNotFound(err) {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(comment, githubIssueCommentFinalizer)
+	return r.Update(ctx, comment)
+}
+
+// releaseComments removes the finalizers of the GithubIssueComments posted on
+// the named GithubIssue, which is gone or being deleted, leaving the comments
+// on GitHub as part of the issue's history.
+func (r *GithubIssueReconciler) releaseComments(ctx context.Context, namespace, name string) error {
+	comments, err := r.commentsOn(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		if controllerutil.RemoveFinalizer(&comments[i], githubIssueCommentFinalizer) {
+			if err := r.Update(ctx, &comments[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// commentsOn returns the GithubIssueComments referencing the named GithubIssue.
+func (r *GithubIssueReconciler) commentsOn(ctx context.Context, namespace, name string) (
+	[]danaiov1alpha1.GithubIssueComment, error) {
+	list := &danaiov1alpha1.GithubIssueCommentList{}
+	if err := r.List(ctx, list, client.InNamespace(namespace),
+		client.MatchingFields{commentIssueRefIndex: name}); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// indexCommentIssueRef extracts the GithubIssue name for commentIssueRefIndex.
+func indexCommentIssueRef(obj client.Object) []string {
+	return []string{obj.(*danaiov1alpha1.GithubIssueComment).Spec.IssueRef.Name}
+}
+
+// commentMarker identifies the GithubIssueComment in the body of its comment.
+func (r *GithubIssueReconciler) commentMarker(comment *danaiov1alpha1.GithubIssueComment) github.Marker {
+	return github.Marker{
+		ClusterID: r.ClusterID,
+		Namespace: comment.Namespace,
+		Name:      comment.Name,
+		UID:       string(comment.UID),
+	}
+}
+
+// requestsForComment maps a GithubIssueComment to the GithubIssue it is posted
+// on. Comments are mapped through spec.issueRef rather than their owner
+// reference, which is only set once the GithubIssue reconciled them.
+func (r *GithubIssueReconciler) requestsForComment(_ context.Context, obj client.Object) []reconcile.Request {
+	comment, ok := obj.(*danaiov1alpha1.GithubIssueComment)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: comment.Namespace,
+		Name:      comment.Spec.IssueRef.Name,
+	}}}
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+const (
+	// credentialsSecretIndex indexes GithubIssues by the names of the Secrets holding their credentials.
+	credentialsSecretIndex = ".spec.credentialsSecrets"
+	// credentialsRefIndex indexes GithubIssues by the name of the GithubCredentials they use.
+	credentialsRefIndex = ".spec.credentialsRef.name"
+)
+
+// Keys of a GitHub App credentials Secret.
+const (
+	appIDKey          = "app-id"
+	installationIDKey = "installation-id"
+	privateKeyKey     = "private-key"
+)
+
+// Optional keys of a credentials Secret pointing at another GitHub instance,
+// such as GitHub Enterprise Server.
+const (
+	// apiURLKey overrides the REST API base URL, e.g. https://ghe.example.com/api/v3.
+	apiURLKey = "api-url"
+	// caConfigMapKey names a ConfigMap in the same namespace holding a CA bundle.
+	caConfigMapKey = "ca-configmap"
+)
+
+// caBundleKey is the key of the PEM encoded CA bundle in a ConfigMap.
+const caBundleKey = "ca.crt"
+
+// credentialsError reports credentials that cannot be loaded from the cluster.
+// It is surfaced through the AuthFailed condition rather than retried.
+type credentialsError struct {
+	message string
+}
+
+func (e *credentialsError) Error() string {
+	return e.message
+}
+
+// forbiddenError reports GithubCredentials the namespace of a GithubIssue may not use.
+// It is surfaced through the Forbidden condition rather than retried.
+type forbiddenError struct {
+	message string
+}
+
+func (e *forbiddenError) Error() string {
+	return e.message
+}
+
+// credentialsLoader loads GitHub credentials from the Secrets and
+// GithubCredentials referenced by an object.
+type credentialsLoader struct {
+	client.Reader
+	// defaults are used for objects that do not reference credentials of their own.
+	defaults github.Credentials
+	// operatorNamespace is the namespace holding the Secrets of GithubCredentials.
+	operatorNamespace string
+}
+
+// load returns the credentials an object in namespace references, falling
+// back to the operator-wide defaults.
+func (l *credentialsLoader) load(ctx context.Context, namespace string, tokenRef *danaiov1alpha1.SecretKeyReference,
+	appRef *danaiov1alpha1.LocalSecretReference,
+	credentialsRef *danaiov1alpha1.LocalCredentialsReference) (github.Credentials, error) {
+	switch {
+	case tokenRef != nil || appRef != nil:
+		return l.secretCredentials(ctx, namespace, tokenRef, appRef)
+	case credentialsRef != nil:
+		return l.sharedCredentials(ctx, namespace, credentialsRef.Name)
+	default:
+		return l.defaults, nil
+	}
+}
+
+// secretCredentials loads a token or GitHub App credentials from Secrets in namespace.
+func (l *credentialsLoader) secretCredentials(ctx context.Context, namespace string,
+	tokenRef *danaiov1alpha1.SecretKeyReference, appRef *danaiov1alpha1.LocalSecretReference) (github.Credentials, error) {
+	if appRef != nil {
+		return l.appCredentials(ctx, namespace, appRef.Name)
+	}
+
+	key := tokenRef.Key
+	if key == "" {
+		key = "token"
+	}
+	data, err := l.secretData(ctx, namespace, tokenRef.Name, key)
+	if err != nil {
+		return github.Credentials{}, err
+	}
+	return l.withEndpoint(ctx, namespace, tokenRef.Name, data, github.Credentials{Token: string(data[key])})
+}
+
+// sharedCredentials loads the credentials of a GithubCredentials object from
+// the operator namespace, provided namespace is allowed to use them.
+func (l *credentialsLoader) sharedCredentials(ctx context.Context, namespace, name string) (github.Credentials, error) {
+	shared := &danaiov1alpha1.GithubCredentials{}
+	err := l.Get(ctx, types.NamespacedName{Name: name}, shared)
+	if apierrors.IsNotFound(err) {
+		return github.Credentials{}, &credentialsError{fmt.Sprintf("githubcredentials %q not found", name)}
+	}
+	if err != nil {
+		return github.Credentials{}, err
+	}
+
+	if err := l.checkNamespaceAllowed(ctx, namespace, shared); err != nil {
+		return github.Credentials{}, err
+	}
+	return l.secretCredentials(ctx, l.operatorNamespace, shared.Spec.TokenSecretRef, shared.Spec.AppSecretRef)
+}
+
+// checkNamespaceAllowed returns a forbiddenError unless the AllowedNamespaces
+// selector of the GithubCredentials matches the labels of namespace.
+func (l *credentialsLoader) checkNamespaceAllowed(ctx context.Context, namespace string,
+	shared *danaiov1alpha1.GithubCredentials) error {
+	if shared.Spec.AllowedNamespaces == nil {
+		return &forbiddenError{fmt.Sprintf("githubcredentials %q allow no namespaces", shared.Name)}
+	}
+	selector, err := metav1.LabelSelectorAsSelector(shared.Spec.AllowedNamespaces)
+	if err != nil {
+		return &credentialsError{fmt.Sprintf("githubcredentials %q has an invalid allowedNamespaces: %v", shared.Name, err)}
+	}
+
+	ns := &corev1.Namespace{}
+	if err := l.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return err
+	}
+	if !selector.Matches(labels.Set(ns.Labels)) {
+		return &forbiddenError{fmt.Sprintf("namespace %q may not use githubcredentials %q", namespace, shared.Name)}
+	}
+	return nil
+}
+
+// appCredentials loads GitHub App credentials from a Secret.
+func (l *credentialsLoader) appCredentials(ctx context.Context, namespace, name string) (github.Credentials, error) {
+	data, err := l.secretData(ctx, namespace, name, appIDKey, installationIDKey, privateKeyKey)
+	if err != nil {
+		return github.Credentials{}, err
+	}
+
+	appID, err := strconv.ParseInt(strings.TrimSpace(string(data[appIDKey])), 10, 64)
+	if err != nil {
+		return github.Credentials{}, &credentialsError{fmt.Sprintf("secret %q has an invalid %s: %v", name, appIDKey, err)}
+	}
+	installationID, err := strconv.ParseInt(strings.TrimSpace(string(data[installationIDKey])), 10, 64)
+	if err != nil {
+		return github.Credentials{}, &credentialsError{
+			fmt.Sprintf("secret %q has an invalid %s: %v", name, installationIDKey, err)}
+	}
+	return l.withEndpoint(ctx, namespace, name, data,
+		github.Credentials{AppID: appID, InstallationID: installationID, PrivateKey: data[privateKeyKey]})
+}
+
+// withEndpoint applies the optional API URL and CA bundle of a credentials Secret to creds.
+func (l *credentialsLoader) withEndpoint(ctx context.Context, namespace, name string, data map[string][]byte,
+	creds github.Credentials) (github.Credentials, error) {
+	if apiURL := strings.TrimSpace(string(data[apiURLKey])); apiURL != "" {
+		if err := github.ValidateBaseURL(apiURL); err != nil {
+			return github.Credentials{}, &credentialsError{fmt.Sprintf("secret %q has an invalid %s: %v", name, apiURLKey, err)}
+		}
+		creds.BaseURL = apiURL
+	}
+	if configMapName := strings.TrimSpace(string(data[caConfigMapKey])); configMapName != "" {
+		caBundle, err := l.caBundle(ctx, namespace, configMapName)
+		if err != nil {
+			return github.Credentials{}, err
+		}
+		creds.CABundle = caBundle
+	}
+	return creds, nil
+}
+
+// caBundle reads the CA bundle from a ConfigMap.
+func (l *credentialsLoader) caBundle(ctx context.Context, namespace, name string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	err := l.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		return nil, &credentialsError{fmt.Sprintf("configmap %q not found", name)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle := configMap.Data[caBundleKey]
+	if caBundle == "" {
+		return nil, &credentialsError{fmt.Sprintf("configmap %q has no key %q", name, caBundleKey)}
+	}
+	return []byte(caBundle), nil
+}
+
+// secretData reads a Secret and makes sure the given keys are set.
+func (l *credentialsLoader) secretData(ctx context.Context, namespace, name string,
+	keys ...string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	err := l.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, &credentialsError{fmt.Sprintf("secret %q not found", name)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return nil, &credentialsError{fmt.Sprintf("secret %q has no key %q", name, key)}
+		}
+	}
+	return secret.Data, nil
+}
+
+// credentials returns the loader of the credentials referenced by GithubIssues.
+func (r *GithubIssueReconciler) credentials() *credentialsLoader {
+	return &credentialsLoader{Reader: r.Client, defaults: r.DefaultCredentials, operatorNamespace: r.OperatorNamespace}
+}
+
+// issueClient returns a GitHub client authenticated for a GithubIssue in
+// namespace with the resolved spec, along with the credentials it uses.
+func (r *GithubIssueReconciler) issueClient(ctx context.Context, namespace string,
+	spec danaiov1alpha1.GithubIssueSpec) (github.IssueClient, github.Credentials, error) {
+	creds, err := r.credentials().load(ctx, namespace, spec.TokenSecretRef, spec.AppSecretRef, spec.CredentialsRef)
+	if err != nil {
+		return nil, creds, err
+	}
+	gh, err := r.GitHub.IssueClient(ctx, creds)
+	return gh, creds, err
+}
+
+// indexCredentialsSecrets extracts the credentials Secret names for credentialsSecretIndex.
+func indexCredentialsSecrets(obj client.Object) []string {
+	spec := obj.(*danaiov1alpha1.GithubIssue).Spec
+	return secretNames(spec.TokenSecretRef, spec.AppSecretRef)
+}
+
+// indexCredentialsRef extracts the GithubCredentials name for credentialsRefIndex.
+func indexCredentialsRef(obj client.Object) []string {
+	spec := obj.(*danaiov1alpha1.GithubIssue).Spec
+	if spec.CredentialsRef == nil {
+		return nil
+	}
+	return []string{spec.CredentialsRef.Name}
+}
+
+// requestsForSecret enqueues the GithubIssues that read their credentials from
+// the Secret, directly or through GithubCredentials when the Secret lives in
+// the operator namespace.
+func (r *GithubIssueReconciler) requestsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.requestsFor(ctx, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{credentialsSecretIndex: obj.GetName()})
+	requests = append(requests, r.requestsForRepositoriesMatching(ctx, obj.GetNamespace(),
+		func(spec danaiov1alpha1.GithubRepositorySpec) bool {
+			return slices.Contains(secretNames(spec.TokenSecretRef, spec.AppSecretRef), obj.GetName())
+		})...)
+	if obj.GetNamespace() != r.OperatorNamespace {
+		return requests
+	}
+
+	sharedList := &danaiov1alpha1.GithubCredentialsList{}
+	if err := r.List(ctx, sharedList); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubCredentials for Secret", "secret", obj.GetName())
+		return requests
+	}
+	for _, shared := range sharedList.Items {
+		if slices.Contains(secretNames(shared.Spec.TokenSecretRef, shared.Spec.AppSecretRef), obj.GetName()) {
+			requests = append(requests, r.requestsForCredentials(ctx, &shared)...)
+		}
+	}
+	return requests
+}
+
+// requestsForCredentials enqueues the GithubIssues that use the GithubCredentials,
+// directly or through their GithubRepository.
+func (r *GithubIssueReconciler) requestsForCredentials(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.requestsFor(ctx, client.MatchingFields{credentialsRefIndex: obj.GetName()})
+	return append(requests, r.requestsForRepositoriesMatching(ctx, "",
+		func(spec danaiov1alpha1.GithubRepositorySpec) bool {
+			return spec.CredentialsRef != nil && spec.CredentialsRef.Name == obj.GetName()
+		})...)
+}
+
+// requestsForNamespace enqueues the GithubIssues of the Namespace that use
+// GithubCredentials, whose access depends on the namespace labels.
+func (r *GithubIssueReconciler) requestsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	githubIssues := &danaiov1alpha1.GithubIssueList{}
+	if err := r.List(ctx, githubIssues, client.InNamespace(obj.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubIssues for Namespace", "namespace", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range githubIssues.Items {
+		if item.Spec.CredentialsRef != nil || item.Spec.RepositoryRef != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// requestsFor enqueues the GithubIssues matching opts.
+func (r *GithubIssueReconciler) requestsFor(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	githubIssues := &danaiov1alpha1.GithubIssueList{}
+	if err := r.List(ctx, githubIssues, opts...); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubIssues")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(githubIssues.Items))
+	for _, item := range githubIssues.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+// secretNames returns the names of the Secrets the references point at.
+func secretNames(tokenRef *danaiov1alpha1.SecretKeyReference, appRef *danaiov1alpha1.LocalSecretReference) []string {
+	var names []string
+	if tokenRef != nil {
+		names = append(names, tokenRef.Name)
+	}
+	if appRef != nil {
+		names = append(names, appRef.Name)
+	}
+	return names
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// maxDriftValueLength caps the values recorded in status.drift so long bodies
+// do not bloat the object.
+const maxDriftValueLength = 256
+
+// diffIssue compares the issue on GitHub with the resolved spec and its
+// desired body. It returns the drifted fields and the patch reverting them.
+func diffIssue(issue *github.Issue, spec danaiov1alpha1.GithubIssueSpec,
+	body string) ([]danaiov1alpha1.FieldDrift, github.IssueRequest) {
+	var drift []danaiov1alpha1.FieldDrift
+	var patch github.IssueRequest
+	record := func(field, desired, actual string) {
+		drift = append(drift, danaiov1alpha1.FieldDrift{
+			Field:   field,
+			Desired: truncate(desired),
+			Actual:  truncate(actual),
+		})
+	}
+
+	if issue.Title != spec.Title {
+		record("title", spec.Title, issue.Title)
+		patch.Title = &spec.Title
+	}
+	if issue.Body != body {
+		record("body", body, issue.Body)
+		patch.Body = &body
+	}
+	if labels, actual := sortedSet(spec.Labels), sortedSet(issue.LabelNames()); !slices.Equal(foldedSet(labels), foldedSet(actual)) {
+		record("labels", strings.Join(labels, ","), strings.Join(actual, ","))
+		patch.Labels = &labels
+	}
+	// GitHub logins are case-insensitive and reported in their canonical case.
+	if assignees, actual := sortedSet(spec.Assignees), sortedSet(issue.AssigneeLogins()); !slices.Equal(foldedSet(assignees), foldedSet(actual)) {
+		record("assignees", strings.Join(assignees, ","), strings.Join(actual, ","))
+		patch.Assignees = &assignees
+	}
+	if spec.Milestone != nil && (issue.Milestone == nil || issue.Milestone.Number != *spec.Milestone) {
+		actual := ""
+		if issue.Milestone != nil {
+			actual = strconv.Itoa(issue.Milestone.Number)
+		}
+		record("milestone", strconv.Itoa(*spec.Milestone), actual)
+		patch.Milestone = spec.Milestone
+	}
+	if state := string(desiredState(spec)); issue.State != state {
+		record("state", state, issue.State)
+		patch.State = &state
+	}
+	return drift, patch
+}
+
+// sortedSet returns the distinct values sorted, never nil.
+func sortedSet(values []string) []string {
+	set := slices.Clone(values)
+	slices.Sort(set)
+	return append([]string{}, slices.Compact(set)...)
+}
+
+// foldedSet returns the distinct values lowercased and sorted, as GitHub
+// matches label names and logins regardless of case.
+func foldedSet(values []string) []string {
+	folded := make([]string, len(values))
+	for i, value := range values {
+		folded[i] = strings.ToLower(value)
+	}
+	return sortedSet(folded)
+}
+
+// truncate cuts value to at most maxDriftValueLength bytes, backing off to
+// the start of a rune so that the result stays valid UTF-8.
+func truncate(value string) string {
+	if len(value) <= maxDriftValueLength {
+		return value
+	}
+	cut := maxDriftValueLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "..."
+}
+
+// syncPolicy returns the sync policy of the spec, defaulting to Enforce.
+func syncPolicy(spec danaiov1alpha1.GithubIssueSpec) danaiov1alpha1.SyncPolicy {
+	if spec.SyncPolicy == "" {
+		return danaiov1alpha1.SyncPolicyEnforce
+	}
+	return spec.SyncPolicy
+}
+
+// setDriftConditions records the drift left on GitHub after a sync. Observed
+// drift makes the issue not Ready, since it no longer matches the spec.
+func (r *GithubIssueReconciler) setDriftConditions(githubIssue *danaiov1alpha1.GithubIssue,
+	policy danaiov1alpha1.SyncPolicy, drift []danaiov1alpha1.FieldDrift) {
+	conditions := &githubIssue.Status.Conditions
+	generation := githubIssue.Generation
+	githubIssue.Status.Drift = nil
+
+	switch {
+	case policy == danaiov1alpha1.SyncPolicyCreateOnly:
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDrifted,
+			Status: metav1.ConditionUnknown, Reason: "CreateOnly", Message: "drift is not tracked",
+			ObservedGeneration: generation})
+	case len(drift) == 0:
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDrifted,
+			Status: metav1.ConditionFalse, Reason: "InSync", ObservedGeneration: generation})
+	case policy == danaiov1alpha1.SyncPolicyEnforce:
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDrifted,
+			Status: metav1.ConditionFalse, Reason: "Reverted", Message: "reverted " + driftFields(drift),
+			ObservedGeneration: generation})
+	default:
+		githubIssue.Status.Drift = drift
+		message := "issue differs from the spec in " + driftFields(drift)
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDrifted,
+			Status: metav1.ConditionTrue, Reason: "Observed", Message: message, ObservedGeneration: generation})
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+			Status: metav1.ConditionFalse, Reason: "Drifted", Message: message, ObservedGeneration: generation})
+	}
+}
+
+func driftFields(drift []danaiov1alpha1.FieldDrift) string {
+	fields := make([]string, 0, len(drift))
+	for _, field := range drift {
+		fields = append(fields, field.Field)
+	}
+	return strings.Join(fields, ", ")
+}
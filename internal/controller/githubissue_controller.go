@@ -18,46 +18,456 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
 )
 
+// githubIssueFinalizer guards the upstream issue until the deletion policy has been applied.
+const githubIssueFinalizer = "dana.io.dana.io/finalizer"
+
+// resyncPeriod is how often an in-sync issue is re-read from GitHub to pick up upstream edits.
+const resyncPeriod = 10 * time.Minute
+
 // GithubIssueReconciler reconciles a GithubIssue object
 type GithubIssueReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// GitHub creates the clients managing the upstream issues.
+	GitHub github.ClientFactory
+	// DefaultCredentials are used for GithubIssues that do not reference credentials of their own.
+	DefaultCredentials github.Credentials
+	// OperatorNamespace is the namespace holding the Secrets of GithubCredentials.
+	OperatorNamespace string
+	// ClusterID identifies this cluster in the ownership markers of issues.
+	ClusterID string
+	// GitHubEvents, if set, enqueues the GithubIssues named by GitHub webhook deliveries.
+	GitHubEvents <-chan event.GenericEvent
+
+	// milestones resolves the milestone titles of specs.
+	milestones milestoneCache
 }
 
 // +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissues,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissues/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissues/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissuecomments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissuecomments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissuecomments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubcredentials,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubrepositories,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githublabels,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubmilestones,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the GithubIssue object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// Reconcile makes sure a GitHub issue matching the GithubIssue spec, merged
+// with the defaults of its GithubRepository, exists. Existing issues are
+// adopted by number or title when the spec asks for it, and found by the
+// number recorded in status afterwards; missing issues are created and edits
+// made on GitHub are handled according to the spec's SyncPolicy. On deletion
+// the spec's DeletionPolicy is applied to the issue before the finalizer is
+// removed.
+//
+// A body template is rendered into the description, a milestone given by
+// title is resolved to its number, and labels of the issue missing in the
+// repository are created first when the spec asks for it.
+//
+// The GithubIssueComments referencing the GithubIssue are reconciled into
+// comments on its issue once the issue is in sync.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.1/pkg/reconcile
 func (r *GithubIssueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	githubIssue := &danaiov1alpha1.GithubIssue{}
+	if err := r.Get(ctx, req.NamespacedName, githubIssue); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.releaseComments(ctx, req.Namespace, req.Name)
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !githubIssue.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, githubIssue); err != nil {
+			return r.syncFailed(ctx, githubIssue, "DeletionFailed", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(githubIssue, githubIssueFinalizer) {
+		if err := r.Update(ctx, githubIssue); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	spec, err := r.resolveSpec(ctx, githubIssue)
+	if err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+	spec, err = r.renderBody(ctx, githubIssue, spec)
+	if err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+	gh, creds, err := r.issueClient(ctx, githubIssue.Namespace, spec)
+	if err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+	spec, err = r.resolveMilestone(ctx, gh, creds, spec)
+	if err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+	if err := r.createMissingLabels(ctx, gh, githubIssue, spec); err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+	issue, drift, err := r.syncIssue(ctx, gh, githubIssue, spec)
+	if github.IsUnprocessable(err) && spec.MilestoneTitle != "" {
+		// The cached number may belong to a milestone that was deleted and
+		// created again since it was listed.
+		r.milestones.invalidate(creds, spec.Repo)
+		spec, err = r.resolveMilestone(ctx, gh, creds, spec)
+		if err != nil {
+			return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+		}
+		issue, drift, err = r.syncIssue(ctx, gh, githubIssue, spec)
+	}
+	if err != nil {
+		return r.syncFailed(ctx, githubIssue, "SyncFailed", err)
+	}
+
+	now := metav1.Now()
+	githubIssue.Status.Repo = spec.Repo
+	githubIssue.Status.Number = issue.Number
+	githubIssue.Status.URL = issue.HTMLURL
+	githubIssue.Status.NodeID = issue.NodeID
+	githubIssue.Status.State = danaiov1alpha1.IssueState(issue.State)
+	githubIssue.Status.LastSyncedAt = &now
+	githubIssue.Status.ObservedGeneration = githubIssue.Generation
+	r.setSyncedConditions(githubIssue)
+	r.setDriftConditions(githubIssue, syncPolicy(spec), drift)
+	if err := r.Status().Update(ctx, githubIssue); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.syncComments(ctx, gh, githubIssue); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+// syncFailed records a failed sync in the status conditions. Credential
+// problems are reported through AuthFailed, and namespaces not allowed to use
+// GithubCredentials through Forbidden; both wait for the referenced objects
+// to change instead of being retried with backoff. Rate limited requests are
+// requeued once the limit resets.
+func (r *GithubIssueReconciler) syncFailed(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue,
+	reason string, err error) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	result, retErr := ctrl.Result{}, err
+	var credsErr *credentialsError
+	var forbiddenErr *forbiddenError
+	var repoErr *repositoryError
+	var adoptionErr *adoptionError
+	var milestoneErr *milestoneError
+	var templateErr *templateError
+	retryAfter, rateLimited := github.RetryAfter(err)
+	switch {
+	case rateLimited:
+		logger.Info("GitHub rate limit exceeded, requeueing", "retryAfter", retryAfter)
+		r.setFailedConditions(githubIssue, "RateLimited", err.Error())
+		result, retErr = ctrl.Result{RequeueAfter: retryAfter}, nil
+	case errors.As(err, &forbiddenErr):
+		r.setForbiddenConditions(githubIssue, err.Error())
+		retErr = nil
+	case errors.As(err, &adoptionErr):
+		r.setAdoptionRefusedConditions(githubIssue, adoptionErr.reason, err.Error())
+		result, retErr = ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.As(err, &repoErr):
+		r.setFailedConditions(githubIssue, "RepositoryNotFound", err.Error())
+		retErr = nil
+	case errors.As(err, &milestoneErr):
+		r.setMilestoneNotFoundConditions(githubIssue, err.Error())
+		result, retErr = ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.As(err, &templateErr):
+		r.setTemplateErrorConditions(githubIssue, templateErr.reason, err.Error())
+		retErr = nil
+	case errors.As(err, &credsErr):
+		r.setAuthFailedConditions(githubIssue, "CredentialsNotFound", err.Error())
+		retErr = nil
+	case errors.Is(err, github.ErrInvalidCredentials):
+		r.setAuthFailedConditions(githubIssue, "InvalidCredentials", err.Error())
+		retErr = nil
+	case github.IsUnauthorized(err):
+		r.setAuthFailedConditions(githubIssue, "Unauthorized", "GitHub rejected the credentials: "+err.Error())
+		result, retErr = ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	default:
+		logger.Error(err, "failed to sync issue with GitHub", "repo", githubIssue.Spec.Repo)
+		r.setFailedConditions(githubIssue, reason, err.Error())
+	}
+
+	if statusErr := r.Status().Update(ctx, githubIssue); statusErr != nil {
+		logger.Error(statusErr, "failed to update GithubIssue status")
+	}
+	return result, retErr
+}
+
+// finalize applies the deletion policy to the upstream issue and releases the
+// finalizer. The finalizer stays in place until GitHub accepted the change.
+func (r *GithubIssueReconciler) finalize(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue) error {
+	if !controllerutil.ContainsFinalizer(githubIssue, githubIssueFinalizer) {
+		return nil
+	}
+
+	if err := r.applyDeletionPolicy(ctx, githubIssue); err != nil {
+		return err
+	}
+	if err := r.releaseComments(ctx, githubIssue.Namespace, githubIssue.Name); err != nil {
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(githubIssue, githubIssueFinalizer)
+	return r.Update(ctx, githubIssue)
+}
+
+// applyDeletionPolicy closes, and with the Lock policy locks, the issue
+// recorded in status. The issue is found by the repository and number
+// recorded in status, so that a GithubRepository deleted first does not keep
+// it open. When no client can be built for it any more, because the
+// credentials were deleted or are refused, the policy is given up on rather
+// than keeping the GithubIssue terminating forever.
+func (r *GithubIssueReconciler) applyDeletionPolicy(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue) error {
+	number := githubIssue.Status.Number
+	policy := githubIssue.Spec.DeletionPolicy
+	if number == 0 || policy == danaiov1alpha1.DeletionPolicyOrphan {
+		return nil
+	}
+
+	spec, err := r.resolveSpec(ctx, githubIssue)
+	var repoErr *repositoryError
+	if errors.As(err, &repoErr) {
+		// Without the GithubRepository, the credentials of the spec itself, or
+		// the default ones, are all there is left.
+		spec, err = *githubIssue.Spec.DeepCopy(), nil
+	}
+	if err != nil {
+		return err
+	}
+	repo := spec.Repo
+	if githubIssue.Status.Repo != "" {
+		repo = githubIssue.Status.Repo
+	}
+	gh, _, err := r.issueClient(ctx, githubIssue.Namespace, spec)
+	if err != nil {
+		return r.skipDeletionPolicy(ctx, githubIssue, err)
+	}
+	if _, err := gh.Close(ctx, repo, number); err != nil {
+		if github.IsNotFound(err) {
+			return nil
+		}
+		return r.skipDeletionPolicy(ctx, githubIssue, err)
+	}
+	if policy == danaiov1alpha1.DeletionPolicyLock {
+		return r.skipDeletionPolicy(ctx, githubIssue, gh.Lock(ctx, repo, number))
+	}
+	return nil
+}
 
-	// TODO(user): your logic here
+// skipDeletionPolicy returns err when it may go away by retrying. Errors
+// caused by credentials that are gone or refused are recorded in the status
+// conditions instead, and nil is returned so that the finalizer is released.
+func (r *GithubIssueReconciler) skipDeletionPolicy(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue,
+	err error) error {
+	if err == nil || !deletionPolicyUnreachable(err) {
+		return err
+	}
 
-	return ctrl.Result{}, nil
+	log.FromContext(ctx).Info("leaving GitHub issue untouched, its credentials are gone", "repo",
+		githubIssue.Status.Repo, "number", githubIssue.Status.Number, "reason", err.Error())
+	r.setFailedConditions(githubIssue, "DeletionPolicySkipped",
+		"deletion policy not applied to the issue: "+err.Error())
+	return client.IgnoreNotFound(r.Status().Update(ctx, githubIssue))
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// syncIssue finds or creates the upstream issue and compares it with the
+// resolved spec, reverting the drift when the sync policy enforces the spec.
+// It returns the drift found. The body carries an ownership marker so that an
+// issue created by a reconcile that failed to record it is found again.
+func (r *GithubIssueReconciler) syncIssue(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue,
+	spec danaiov1alpha1.GithubIssueSpec) (*github.Issue, []danaiov1alpha1.FieldDrift, error) {
+	issue, err := r.findIssue(ctx, gh, githubIssue, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := github.WithMarker(spec.Description, r.marker(githubIssue))
+	if issue == nil {
+		log.FromContext(ctx).Info("creating GitHub issue", "repo", spec.Repo, "title", spec.Title)
+		request := github.IssueRequest{Title: &spec.Title, Body: &body, Milestone: spec.Milestone}
+		if len(spec.Labels) > 0 {
+			request.Labels = &spec.Labels
+		}
+		if len(spec.Assignees) > 0 {
+			request.Assignees = &spec.Assignees
+		}
+		issue, err = gh.Create(ctx, spec.Repo, request)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	policy := syncPolicy(spec)
+	if policy == danaiov1alpha1.SyncPolicyCreateOnly {
+		return issue, nil, nil
+	}
+	drift, patch := diffIssue(issue, spec, body)
+	if len(drift) == 0 || policy == danaiov1alpha1.SyncPolicyObserve {
+		return issue, drift, nil
+	}
+
+	log.FromContext(ctx).Info("patching drifted GitHub issue", "repo", spec.Repo, "number", issue.Number,
+		"fields", driftFields(drift))
+	issue, err = gh.Update(ctx, spec.Repo, issue.Number, patch)
+	return issue, drift, err
+}
+
+// desiredState returns the state requested by the spec, defaulting to open.
+func desiredState(spec danaiov1alpha1.GithubIssueSpec) danaiov1alpha1.IssueState {
+	if spec.State == "" {
+		return danaiov1alpha1.IssueStateOpen
+	}
+	return spec.State
+}
+
+func (r *GithubIssueReconciler) setSyncedConditions(githubIssue *danaiov1alpha1.GithubIssue) {
+	conditions := &githubIssue.Status.Conditions
+	generation := githubIssue.Generation
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionSynced,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "issue is in sync with GitHub",
+		ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "issue matches the spec",
+		ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDegraded,
+		Status: metav1.ConditionFalse, Reason: "Synced", ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionAuthFailed,
+		Status: metav1.ConditionFalse, Reason: "Authenticated", ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionForbidden,
+		Status: metav1.ConditionFalse, Reason: "Allowed", ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionAdoptionRefused,
+		Status: metav1.ConditionFalse, Reason: "Bound", ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionMilestoneNotFound,
+		Status: metav1.ConditionFalse, Reason: "Resolved", ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionTemplateError,
+		Status: metav1.ConditionFalse, Reason: "Rendered", ObservedGeneration: generation})
+}
+
+func (r *GithubIssueReconciler) setFailedConditions(githubIssue *danaiov1alpha1.GithubIssue, reason, message string) {
+	conditions := &githubIssue.Status.Conditions
+	generation := githubIssue.Generation
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionSynced,
+		Status: metav1.ConditionFalse, Reason: reason, Message: message, ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse, Reason: reason, Message: message, ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionDegraded,
+		Status: metav1.ConditionTrue, Reason: reason, Message: message, ObservedGeneration: generation})
+}
+
+func (r *GithubIssueReconciler) setAuthFailedConditions(githubIssue *danaiov1alpha1.GithubIssue,
+	reason, message string) {
+	r.setFailedConditions(githubIssue, reason, message)
+	meta.SetStatusCondition(&githubIssue.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionAuthFailed,
+		Status: metav1.ConditionTrue, Reason: reason, Message: message, ObservedGeneration: githubIssue.Generation})
+}
+
+func (r *GithubIssueReconciler) setForbiddenConditions(githubIssue *danaiov1alpha1.GithubIssue, message string) {
+	const reason = "NamespaceNotAllowed"
+	r.setFailedConditions(githubIssue, reason, message)
+	meta.SetStatusCondition(&githubIssue.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionForbidden,
+		Status: metav1.ConditionTrue, Reason: reason, Message: message, ObservedGeneration: githubIssue.Generation})
+}
+
+func (r *GithubIssueReconciler) setAdoptionRefusedConditions(githubIssue *danaiov1alpha1.GithubIssue,
+	reason, message string) {
+	r.setFailedConditions(githubIssue, reason, message)
+	meta.SetStatusCondition(&githubIssue.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionAdoptionRefused,
+		Status: metav1.ConditionTrue, Reason: reason, Message: message, ObservedGeneration: githubIssue.Generation})
+}
+
+func (r *GithubIssueReconciler) setMilestoneNotFoundConditions(githubIssue *danaiov1alpha1.GithubIssue,
+	message string) {
+	const reason = "MilestoneNotFound"
+	r.setFailedConditions(githubIssue, reason, message)
+	meta.SetStatusCondition(&githubIssue.Status.Conditions, metav1.Condition{
+		Type: danaiov1alpha1.ConditionMilestoneNotFound, Status: metav1.ConditionTrue, Reason: reason,
+		Message: message, ObservedGeneration: githubIssue.Generation})
+}
+
+func (r *GithubIssueReconciler) setTemplateErrorConditions(githubIssue *danaiov1alpha1.GithubIssue,
+	reason, message string) {
+	r.setFailedConditions(githubIssue, reason, message)
+	meta.SetStatusCondition(&githubIssue.Status.Conditions, metav1.Condition{
+		Type: danaiov1alpha1.ConditionTemplateError, Status: metav1.ConditionTrue, Reason: reason,
+		Message: message, ObservedGeneration: githubIssue.Generation})
+}
+
+// SetupWithManager sets up the controller with the Manager. Secrets and
+// ConfigMaps are watched by metadata only; the manager must read them through
+// the API server, see UncachedObjects.
 func (r *GithubIssueReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &danaiov1alpha1.GithubIssue{},
+		credentialsSecretIndex, indexCredentialsSecrets); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &danaiov1alpha1.GithubIssue{},
+		credentialsRefIndex, indexCredentialsRef); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &danaiov1alpha1.GithubIssue{},
+		repositoryRefIndex, indexRepositoryRef); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &danaiov1alpha1.GithubIssue{},
+		bodyTemplateConfigMapIndex, indexBodyTemplateConfigMap); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &danaiov1alpha1.GithubIssueComment{},
+		commentIssueRefIndex, indexCommentIssueRef); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&danaiov1alpha1.GithubIssue{}).
-		Named("githubissue").
-		Complete(r)
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret), builder.OnlyMetadata).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.requestsForConfigMap),
+			builder.OnlyMetadata).
+		Watches(&danaiov1alpha1.GithubRepository{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRepository)).
+		Watches(&danaiov1alpha1.GithubIssueComment{}, handler.EnqueueRequestsFromMapFunc(r.requestsForComment)).
+		Watches(&danaiov1alpha1.GithubMilestone{}, handler.EnqueueRequestsFromMapFunc(r.requestsForMilestone)).
+		Watches(&danaiov1alpha1.GithubCredentials{}, handler.EnqueueRequestsFromMapFunc(r.requestsForCredentials)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	if r.GitHubEvents != nil {
+		b = b.WatchesRawSource(source.Channel(r.GitHubEvents, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Named("githubissue").Complete(r)
 }
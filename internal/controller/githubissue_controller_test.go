@@ -18,32 +18,54 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
 )
 
 var _ = Describe("GithubIssue Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
+		const repo = "TalDebi/GithubIssue"
+		const operatorNamespace = "githubissue-system"
 
 		ctx := context.Background()
 
 		typeNamespacedName := types.NamespacedName{
 			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Namespace: "default",
 		}
-		githubissue := &danaiov1alpha1.GithubIssue{}
+		var fake *github.FakeClient
+		var controllerReconciler *GithubIssueReconciler
 
 		BeforeEach(func() {
+			fake = github.NewFakeClient()
+			controllerReconciler = &GithubIssueReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				GitHub:            fake,
+				OperatorNamespace: operatorNamespace,
+				ClusterID:         "test-cluster",
+			}
+
 			By("creating the custom resource for the Kind GithubIssue")
+			githubissue := &danaiov1alpha1.GithubIssue{}
 			err := k8sClient.Get(ctx, typeNamespacedName, githubissue)
 			if err != nil && errors.IsNotFound(err) {
 				resource := &danaiov1alpha1.GithubIssue{
@@ -51,34 +73,956 @@ var _ = Describe("GithubIssue Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: danaiov1alpha1.GithubIssueSpec{
+						Repo:        repo,
+						Title:       "Test issue",
+						Description: "Created by the controller tests",
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &danaiov1alpha1.GithubIssue{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance GithubIssue")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			if len(resource.Finalizers) > 0 {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
 		})
-		It("should successfully reconcile the resource", func() {
+
+		reconcileResource := func() *danaiov1alpha1.GithubIssue {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		It("should create a missing issue and record it in status", func() {
 			By("Reconciling the created resource")
-			controllerReconciler := &GithubIssueReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(Equal(1))
+			issue := fake.Issue(repo, 1)
+			Expect(issue).NotTo(BeNil())
+			Expect(issue.Title).To(Equal("Test issue"))
+			Expect(github.StripMarker(issue.Body)).To(Equal("Created by the controller tests"))
+			marker, ok := github.ParseMarker(issue.Body)
+			Expect(ok).To(BeTrue())
+			Expect(marker).To(Equal(github.Marker{
+				ClusterID: "test-cluster", Namespace: "default", Name: resourceName, UID: string(resource.UID),
+			}))
+
+			Expect(resource.Status.Number).To(Equal(1))
+			Expect(resource.Status.URL).To(Equal(issue.HTMLURL))
+			Expect(resource.Status.NodeID).To(Equal(issue.NodeID))
+			Expect(resource.Status.State).To(Equal(danaiov1alpha1.IssueStateOpen))
+			Expect(resource.Status.LastSyncedAt).NotTo(BeNil())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+
+			By("Reconciling again by number without creating a duplicate")
+			reconcileResource()
+			Expect(fake.Calls("Create")).To(Equal(1))
+			Expect(fake.Calls("Get")).To(Equal(1))
+		})
+
+		updateSpec := func(update func(spec *danaiov1alpha1.GithubIssueSpec)) {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			update(&resource.Spec)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+		}
+
+		It("should adopt an existing issue with the same title when asked to", func() {
+			fake.AddIssue(repo, "Some other issue", "")
+			existing := fake.AddIssue(repo, "Test issue", "Created by the controller tests")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.AdoptExisting = true })
+
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(BeZero())
+			Expect(resource.Status.Number).To(Equal(existing.Number))
+			By("marking the adopted issue as owned")
+			marker, ok := github.ParseMarker(fake.Issue(repo, existing.Number).Body)
+			Expect(ok).To(BeTrue())
+			Expect(marker.UID).To(Equal(string(resource.UID)))
+		})
+
+		It("should not adopt issues with the same title by default", func() {
+			fake.AddIssue(repo, "Test issue", "Created by the controller tests")
+
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(Equal(1))
+			Expect(resource.Status.Number).To(Equal(2))
+		})
+
+		It("should find an issue created before its number was recorded", func() {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			fake.AddIssue(repo, "Test issue", "Created by the controller tests")
+			created := fake.AddIssue(repo, "Test issue", github.WithMarker("Created by the controller tests",
+				github.Marker{ClusterID: "test-cluster", Namespace: "default", Name: resourceName, UID: string(resource.UID)}))
+
+			resource = reconcileResource()
+
+			Expect(fake.Calls("Create")).To(BeZero())
+			Expect(fake.Calls("Update")).To(BeZero())
+			Expect(resource.Status.Number).To(Equal(created.Number))
+		})
+
+		It("should not take over issues marked by another cluster", func() {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			fake.AddIssue(repo, "Test issue", github.WithMarker("Created by the controller tests",
+				github.Marker{ClusterID: "other-cluster", Namespace: "default", Name: resourceName, UID: string(resource.UID)}))
+
+			resource = reconcileResource()
+
+			Expect(fake.Calls("Create")).To(Equal(1))
+			Expect(resource.Status.Number).To(Equal(2))
+		})
+
+		It("should adopt the issue with the given number and enforce the spec", func() {
+			fake.AddIssue(repo, "Some other issue", "")
+			existing := fake.AddIssue(repo, "Legacy title", "Legacy body")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.IssueNumber = &existing.Number })
+
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(BeZero())
+			Expect(resource.Status.Number).To(Equal(existing.Number))
+			Expect(resource.Status.URL).To(Equal(existing.HTMLURL))
+			Expect(fake.Issue(repo, existing.Number).Title).To(Equal("Test issue"))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions,
+				danaiov1alpha1.ConditionAdoptionRefused)).To(BeTrue())
+		})
+
+		It("should refuse to adopt an issue owned by another GithubIssue", func() {
+			existing := fake.AddIssue(repo, "Owned issue", "")
+			owner := &danaiov1alpha1.GithubIssue{
+				ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default"},
+				Spec:       danaiov1alpha1.GithubIssueSpec{Repo: repo, Title: "Owned issue"},
+			}
+			Expect(k8sClient.Create(ctx, owner)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, owner))).To(Succeed())
+			})
+			owner.Status.Repo = repo
+			owner.Status.Number = existing.Number
+			Expect(k8sClient.Status().Update(ctx, owner)).To(Succeed())
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.IssueNumber = &existing.Number })
+
+			resource := reconcileResource()
+
+			condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAdoptionRefused)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("IssueAlreadyOwned"))
+			Expect(condition.Message).To(ContainSubstring("default/owner"))
+			Expect(resource.Status.Number).To(BeZero())
+			Expect(fake.Calls("Update")).To(BeZero())
+		})
+
+		It("should patch title and body when the spec drifts", func() {
+			reconcileResource()
+
+			By("Editing the spec")
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Title = "Renamed issue"
+			resource.Spec.Description = "Updated body"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			resource = reconcileResource()
+
+			issue := fake.Issue(repo, resource.Status.Number)
+			Expect(issue.Title).To(Equal("Renamed issue"))
+			Expect(github.StripMarker(issue.Body)).To(Equal("Updated body"))
+			Expect(fake.Calls("Create")).To(Equal(1))
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+		})
+
+		Context("when the issue is edited on GitHub", func() {
+			editUpstream := func(number int) {
+				title, state := "Edited on GitHub", github.StateClosed
+				labels := []string{"wontfix"}
+				_, err := fake.Update(ctx, repo, number, github.IssueRequest{Title: &title, State: &state, Labels: &labels})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("should revert the edits with the Enforce policy", func() {
+				number := reconcileResource().Status.Number
+				editUpstream(number)
+
+				resource := reconcileResource()
+
+				issue := fake.Issue(repo, number)
+				Expect(issue.Title).To(Equal("Test issue"))
+				Expect(issue.State).To(Equal(github.StateOpen))
+				Expect(issue.Labels).To(BeEmpty())
+				Expect(resource.Status.Drift).To(BeEmpty())
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionDrifted)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal("Reverted"))
+				Expect(condition.Message).To(Equal("reverted title, labels, state"))
+			})
+
+			It("should record the drift without touching GitHub with the Observe policy", func() {
+				updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.SyncPolicy = danaiov1alpha1.SyncPolicyObserve })
+				number := reconcileResource().Status.Number
+				editUpstream(number)
+				updates := fake.Calls("Update")
+
+				resource := reconcileResource()
+
+				Expect(fake.Calls("Update")).To(Equal(updates))
+				Expect(fake.Issue(repo, number).Title).To(Equal("Edited on GitHub"))
+				Expect(resource.Status.Drift).To(ConsistOf(
+					danaiov1alpha1.FieldDrift{Field: "title", Desired: "Test issue", Actual: "Edited on GitHub"},
+					danaiov1alpha1.FieldDrift{Field: "labels", Desired: "", Actual: "wontfix"},
+					danaiov1alpha1.FieldDrift{Field: "state", Desired: "open", Actual: "closed"},
+				))
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionDrifted)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+			})
+
+			It("should truncate long drifted values on a rune boundary", func() {
+				updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.SyncPolicy = danaiov1alpha1.SyncPolicyObserve })
+				number := reconcileResource().Status.Number
+				body := "a" + strings.Repeat("é", 200)
+				_, err := fake.Update(ctx, repo, number, github.IssueRequest{Body: &body})
+				Expect(err).NotTo(HaveOccurred())
+
+				resource := reconcileResource()
+
+				Expect(resource.Status.Drift).To(HaveLen(1))
+				actual := resource.Status.Drift[0].Actual
+				Expect(utf8.ValidString(actual)).To(BeTrue())
+				Expect(actual).To(HavePrefix("aéé"))
+				Expect(actual).To(HaveSuffix("é..."))
+				Expect(len(actual)).To(BeNumerically("<=", 256+len("...")))
+			})
+
+			It("should match label names regardless of case", func() {
+				updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.Labels = []string{"bug"} })
+				number := reconcileResource().Status.Number
+				labels := []string{"Bug"}
+				_, err := fake.Update(ctx, repo, number, github.IssueRequest{Labels: &labels})
+				Expect(err).NotTo(HaveOccurred())
+				updates := fake.Calls("Update")
+
+				resource := reconcileResource()
+
+				Expect(fake.Calls("Update")).To(Equal(updates))
+				Expect(resource.Status.Drift).To(BeEmpty())
+			})
+
+			It("should match assignee logins regardless of case", func() {
+				updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.Assignees = []string{"taldebi"} })
+				number := reconcileResource().Status.Number
+				assignees := []string{"TalDebi"}
+				_, err := fake.Update(ctx, repo, number, github.IssueRequest{Assignees: &assignees})
+				Expect(err).NotTo(HaveOccurred())
+				updates := fake.Calls("Update")
+
+				resource := reconcileResource()
+
+				Expect(fake.Calls("Update")).To(Equal(updates))
+				Expect(resource.Status.Drift).To(BeEmpty())
+			})
+
+			It("should leave the issue alone with the CreateOnly policy", func() {
+				updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.SyncPolicy = danaiov1alpha1.SyncPolicyCreateOnly })
+				number := reconcileResource().Status.Number
+				editUpstream(number)
+				updates := fake.Calls("Update")
+
+				resource := reconcileResource()
+
+				Expect(fake.Calls("Update")).To(Equal(updates))
+				Expect(resource.Status.Drift).To(BeEmpty())
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+			})
+		})
+
+		It("should create missing labels when asked to", func() {
+			fake.AddLabel(repo, github.Label{Name: "Bug", Color: "d73a4a"})
+			Expect(k8sClient.Create(ctx, &danaiov1alpha1.GithubLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "declared", Namespace: "default"},
+				Spec:       danaiov1alpha1.GithubLabelSpec{Repo: repo, Name: "area/docs"},
+			})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, &danaiov1alpha1.GithubLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "declared", Namespace: "default"},
+				})).To(Succeed())
+			})
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.Labels = []string{"bug", "triage", "area/docs"}
+				spec.CreateMissingLabels = true
+			})
+
+			reconcileResource()
+
+			Expect(fake.Labels(repo)).To(ConsistOf(
+				github.Label{Name: "Bug", Color: "d73a4a"},
+				github.Label{Name: "triage", Color: danaiov1alpha1.DefaultLabelColor},
+			))
+			Expect(fake.Issue(repo, 1).LabelNames()).To(ConsistOf("bug", "triage", "area/docs"))
+		})
+
+		It("should not create missing labels by default", func() {
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.Labels = []string{"triage"} })
+
+			reconcileResource()
+
+			Expect(fake.Calls("ListLabels")).To(BeZero())
+			Expect(fake.Labels(repo)).To(BeEmpty())
+		})
+
+		It("should resolve the milestone title to its number", func() {
+			fake.AddMilestone(repo, "v0.9")
+			v1 := fake.AddMilestone(repo, "v1.0")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.MilestoneTitle = "v1.0" })
+
+			resource := reconcileResource()
+
+			Expect(fake.Issue(repo, 1).Milestone.Number).To(Equal(v1.Number))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions,
+				danaiov1alpha1.ConditionMilestoneNotFound)).To(BeTrue())
+
+			By("looking the title up in the cache afterwards")
+			reconcileResource()
+			Expect(fake.Calls("ListMilestones")).To(Equal(1))
+		})
+
+		It("should move the issue to the milestone requested by the spec", func() {
+			fake.AddMilestone(repo, "v1.0")
+			v2 := fake.AddMilestone(repo, "v2.0")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.MilestoneTitle = "v1.0" })
+			reconcileResource()
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.MilestoneTitle = "v2.0" })
+
+			reconcileResource()
+
+			Expect(fake.Issue(repo, 1).Milestone.Number).To(Equal(v2.Number))
+		})
+
+		It("should list the milestones again when GitHub rejects the cached number", func() {
+			v1 := fake.AddMilestone(repo, "v1.0")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.MilestoneTitle = "v1.0" })
+			reconcileResource()
+
+			By("creating the milestone again under a new number")
+			Expect(fake.DeleteMilestone(ctx, repo, v1.Number)).To(Succeed())
+			recreated := fake.AddMilestone(repo, "v1.0")
+			resource := reconcileResource()
+
+			Expect(fake.Issue(repo, 1).Milestone.Number).To(Equal(recreated.Number))
+			Expect(fake.Calls("ListMilestones")).To(Equal(2))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should cache milestones per GitHub instance and credentials", func() {
+			fake.AddMilestone(repo, "v1.0")
+			cache := &milestoneCache{}
+			for _, creds := range []github.Credentials{
+				{Token: "first"},
+				{Token: "second"},
+				{Token: "first", BaseURL: "https://ghe.example.com/api/v3"},
+				{AppID: 1, InstallationID: 2, PrivateKey: []byte("key")},
+			} {
+				_, err := cache.lookup(ctx, fake, creds, repo, "v1.0")
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(fake.Calls("ListMilestones")).To(Equal(4))
+
+			_, err := cache.lookup(ctx, fake, github.Credentials{Token: "first"}, strings.ToUpper(repo), "v1.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fake.Calls("ListMilestones")).To(Equal(4))
+		})
+
+		It("should report MilestoneNotFound until the milestone exists", func() {
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) { spec.MilestoneTitle = "v1.0" })
+
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(BeZero())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions,
+				danaiov1alpha1.ConditionMilestoneNotFound)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+
+			By("finding the milestone once it was created")
+			milestone := fake.AddMilestone(repo, "v1.0")
+			resource = reconcileResource()
+
+			Expect(fake.Issue(repo, 1).Milestone.Number).To(Equal(milestone.Number))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should render the body template with the template data", func() {
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.Description = ""
+				spec.Labels = []string{"incident"}
+				spec.BodyTemplate = &danaiov1alpha1.BodyTemplate{
+					Inline: "## {{ .Title }}\nService: {{ .Values.service | upper }}\nLabels: {{ join \", \" .Labels }}",
+				}
+				spec.TemplateData = map[string]string{"service": "orders"}
+			})
+
+			resource := reconcileResource()
+
+			Expect(github.StripMarker(fake.Issue(repo, 1).Body)).To(Equal(
+				"## Test issue\nService: ORDERS\nLabels: incident"))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions,
+				danaiov1alpha1.ConditionTemplateError)).To(BeTrue())
+		})
+
+		It("should render the body template again when its ConfigMap changes", func() {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "incident-template", Namespace: "default"},
+				Data:       map[string]string{"body": "Impact: {{ .Values.impact }}"},
 			}
+			Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, configMap))).To(Succeed())
+			})
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.Description = ""
+				spec.BodyTemplate = &danaiov1alpha1.BodyTemplate{
+					ConfigMapRef: &danaiov1alpha1.ConfigMapKeyReference{Name: configMap.Name, Key: "body"},
+				}
+				spec.TemplateData = map[string]string{"impact": "checkout fails"}
+			})
+			reconcileResource()
+			Expect(github.StripMarker(fake.Issue(repo, 1).Body)).To(Equal("Impact: checkout fails"))
+
+			By("updating the template in the ConfigMap")
+			configMap.Data["body"] = "Impact: {{ .Values.impact | title }}"
+			Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+			reconcileResource()
+
+			Expect(github.StripMarker(fake.Issue(repo, 1).Body)).To(Equal("Impact: Checkout Fails"))
+			Expect(fake.Calls("Update")).To(Equal(1))
+		})
+
+		It("should report TemplateError until the template renders", func() {
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.Description = ""
+				spec.BodyTemplate = &danaiov1alpha1.BodyTemplate{
+					Inline: `Owner: {{ required "owner is required" .Values.owner }}`,
+				}
+			})
+
+			resource := reconcileResource()
+
+			Expect(fake.Calls("Create")).To(BeZero())
+			condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionTemplateError)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("RenderFailed"))
+			Expect(condition.Message).To(ContainSubstring("owner is required"))
+
+			By("rendering once the data is complete")
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.TemplateData = map[string]string{"owner": "TalDebi"}
+			})
+			resource = reconcileResource()
+
+			Expect(github.StripMarker(fake.Issue(repo, 1).Body)).To(Equal("Owner: TalDebi"))
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions,
+				danaiov1alpha1.ConditionTemplateError)).To(BeTrue())
+		})
+
+		It("should report TemplateError when the template ConfigMap does not exist", func() {
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.Description = ""
+				spec.BodyTemplate = &danaiov1alpha1.BodyTemplate{
+					ConfigMapRef: &danaiov1alpha1.ConfigMapKeyReference{Name: "missing", Key: "template"},
+				}
+			})
+
+			resource := reconcileResource()
+
+			condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionTemplateError)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("ConfigMapNotFound"))
+			Expect(fake.Calls("Create")).To(BeZero())
+		})
+
+		It("should report a degraded condition when GitHub fails", func() {
+			fake.SetWriteError(fmt.Errorf("injected failure"))
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
+			Expect(err).To(HaveOccurred())
+
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		})
+
+		It("should requeue after the rate limit resets", func() {
+			fake.SetWriteError(&github.RateLimitError{RetryAfter: 17 * time.Minute, Message: "403 Forbidden"})
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(17 * time.Minute))
+
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("RateLimited"))
+		})
+
+		setDeletionPolicy := func(policy danaiov1alpha1.DeletionPolicy) {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.DeletionPolicy = policy
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+		}
+
+		deleteResource := func() {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		}
+
+		It("should keep the finalizer until the issue is closed", func() {
+			resource := reconcileResource()
+			Expect(controllerutil.ContainsFinalizer(resource, githubIssueFinalizer)).To(BeTrue())
+			number := resource.Status.Number
+
+			By("Deleting the resource while GitHub rejects writes")
+			deleteResource()
+			fake.SetWriteError(fmt.Errorf("injected failure"))
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			resource = &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(controllerutil.ContainsFinalizer(resource, githubIssueFinalizer)).To(BeTrue())
+			Expect(fake.Issue(repo, number).State).To(Equal("open"))
+
+			By("Reconciling again once GitHub accepts writes")
+			fake.SetWriteError(nil)
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fake.Issue(repo, number).State).To(Equal("closed"))
+			err = k8sClient.Get(ctx, typeNamespacedName, &danaiov1alpha1.GithubIssue{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should release the finalizer when the credentials are gone", func() {
+			number := reconcileResource().Status.Number
+			updateSpec(func(spec *danaiov1alpha1.GithubIssueSpec) {
+				spec.TokenSecretRef = &danaiov1alpha1.SecretKeyReference{Name: "deleted-token", Key: "token"}
+			})
+			deleteResource()
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fake.Issue(repo, number).State).To(Equal("open"))
+			err = k8sClient.Get(ctx, typeNamespacedName, &danaiov1alpha1.GithubIssue{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should close and lock the issue with the Lock policy", func() {
+			number := reconcileResource().Status.Number
+			setDeletionPolicy(danaiov1alpha1.DeletionPolicyLock)
+			deleteResource()
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fake.Issue(repo, number).State).To(Equal("closed"))
+			Expect(fake.IsLocked(repo, number)).To(BeTrue())
+			err = k8sClient.Get(ctx, typeNamespacedName, &danaiov1alpha1.GithubIssue{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should leave the issue untouched with the Orphan policy", func() {
+			number := reconcileResource().Status.Number
+			setDeletionPolicy(danaiov1alpha1.DeletionPolicyOrphan)
+			patches := fake.Calls("Update")
+			deleteResource()
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			Expect(fake.Issue(repo, number).State).To(Equal("open"))
+			Expect(fake.Calls("Update")).To(Equal(patches))
+			err = k8sClient.Get(ctx, typeNamespacedName, &danaiov1alpha1.GithubIssue{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		setTokenSecretRef := func(ref *danaiov1alpha1.SecretKeyReference) {
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.TokenSecretRef = ref
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+		}
+
+		Context("with a token Secret", func() {
+			const secretName = "github-token"
+
+			BeforeEach(func() {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("issue-token")},
+				}
+				Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			})
+
+			It("should authenticate with the referenced token", func() {
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "token"})
+				fake.RequireToken("issue-token")
+
+				resource := reconcileResource()
+
+				Expect(fake.Token()).To(Equal("issue-token"))
+				Expect(resource.Status.Number).To(Equal(1))
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)).To(BeTrue())
+			})
+
+			It("should report AuthFailed when the key is missing", func() {
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "missing"})
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring(`has no key "missing"`))
+				Expect(fake.Calls("Create")).To(BeZero())
+			})
+
+			It("should report AuthFailed when GitHub rejects the token", func() {
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "token"})
+				fake.RequireToken("another-token")
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal("Unauthorized"))
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+			})
+		})
+
+		It("should report AuthFailed when the token Secret does not exist", func() {
+			setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: "does-not-exist", Key: "token"})
+
+			resource := reconcileResource()
+
+			condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring(`secret "does-not-exist" not found`))
+		})
+
+		It("should authenticate as the GitHub App from the referenced Secret", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+				Data: map[string][]byte{
+					"app-id":          []byte("7"),
+					"installation-id": []byte("42"),
+					"private-key":     []byte("pem"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			})
+
+			resource := &danaiov1alpha1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.AppSecretRef = &danaiov1alpha1.LocalSecretReference{Name: "github-app"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			reconcileResource()
+
+			Expect(fake.Credentials()).To(Equal(github.Credentials{
+				AppID:          7,
+				InstallationID: 42,
+				PrivateKey:     []byte("pem"),
+			}))
+		})
+
+		Context("with a GithubRepository", func() {
+			const repositoryName = "operator-repo"
+
+			setRepositoryRef := func() {
+				resource := &danaiov1alpha1.GithubIssue{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.Repo = ""
+				resource.Spec.Labels = []string{"bug"}
+				resource.Spec.RepositoryRef = &danaiov1alpha1.LocalRepositoryReference{Name: repositoryName}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			}
+
+			It("should merge the repository defaults into the issue", func() {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "repo-token", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("repo-token")},
+				}
+				Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+				repository := &danaiov1alpha1.GithubRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: repositoryName, Namespace: "default"},
+					Spec: danaiov1alpha1.GithubRepositorySpec{
+						Owner:            "TalDebi",
+						Name:             "operator",
+						TokenSecretRef:   &danaiov1alpha1.SecretKeyReference{Name: "repo-token", Key: "token"},
+						DefaultLabels:    []string{"operator", "bug"},
+						DefaultAssignees: []string{"TalDebi"},
+						BodyFooter:       "Managed by the operator.",
+					},
+				}
+				Expect(k8sClient.Create(ctx, repository)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, repository))).To(Succeed())
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+				})
+				setRepositoryRef()
+
+				resource := reconcileResource()
+
+				Expect(resource.Status.Repo).To(Equal("TalDebi/operator"))
+				issue := fake.Issue("TalDebi/operator", resource.Status.Number)
+				Expect(issue).NotTo(BeNil())
+				Expect(issue.LabelNames()).To(Equal([]string{"bug", "operator"}))
+				Expect(issue.AssigneeLogins()).To(Equal([]string{"TalDebi"}))
+				Expect(github.StripMarker(issue.Body)).To(Equal("Created by the controller tests\n\nManaged by the operator."))
+				Expect(fake.Token()).To(Equal("repo-token"))
+
+				By("reconciling again without drift")
+				reconcileResource()
+				Expect(fake.Calls("Update")).To(BeZero())
+			})
+
+			It("should close the issue recorded in status once the GithubRepository is gone", func() {
+				repository := &danaiov1alpha1.GithubRepository{
+					ObjectMeta: metav1.ObjectMeta{Name: repositoryName, Namespace: "default"},
+					Spec:       danaiov1alpha1.GithubRepositorySpec{Owner: "TalDebi", Name: "operator"},
+				}
+				Expect(k8sClient.Create(ctx, repository)).To(Succeed())
+				setRepositoryRef()
+				number := reconcileResource().Status.Number
+
+				Expect(k8sClient.Delete(ctx, repository)).To(Succeed())
+				resource := &danaiov1alpha1.GithubIssue{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fake.Issue("TalDebi/operator", number).State).To(Equal("closed"))
+				err = k8sClient.Get(ctx, typeNamespacedName, &danaiov1alpha1.GithubIssue{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should wait for a missing GithubRepository", func() {
+				setRepositoryRef()
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal("RepositoryNotFound"))
+				Expect(fake.Calls("Create")).To(BeZero())
+			})
+		})
+
+		Context("with shared GithubCredentials", func() {
+			const credentialsName = "shared-token"
+			const namespaceLabel = "dana.io/github-credentials"
+
+			setNamespaceLabel := func(value string) {
+				namespace := &corev1.Namespace{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "default"}, namespace)).To(Succeed())
+				if value == "" {
+					delete(namespace.Labels, namespaceLabel)
+				} else {
+					if namespace.Labels == nil {
+						namespace.Labels = map[string]string{}
+					}
+					namespace.Labels[namespaceLabel] = value
+				}
+				Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+			}
+
+			createCredentials := func(allowed *metav1.LabelSelector) {
+				shared := &danaiov1alpha1.GithubCredentials{
+					ObjectMeta: metav1.ObjectMeta{Name: credentialsName},
+					Spec: danaiov1alpha1.GithubCredentialsSpec{
+						TokenSecretRef:    &danaiov1alpha1.SecretKeyReference{Name: "shared-token", Key: "token"},
+						AllowedNamespaces: allowed,
+					},
+				}
+				Expect(k8sClient.Create(ctx, shared)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, shared))).To(Succeed())
+				})
+			}
+
+			setCredentialsRef := func() {
+				resource := &danaiov1alpha1.GithubIssue{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				resource.Spec.CredentialsRef = &danaiov1alpha1.LocalCredentialsReference{Name: credentialsName}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: operatorNamespace}}
+				Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, namespace))).To(Succeed())
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared-token", Namespace: operatorNamespace},
+					Data:       map[string][]byte{"token": []byte("shared-token")},
+				}
+				Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+					setNamespaceLabel("")
+				})
+				setCredentialsRef()
+			})
+
+			It("should authenticate with the Secret in the operator namespace", func() {
+				setNamespaceLabel("shared")
+				createCredentials(&metav1.LabelSelector{MatchLabels: map[string]string{namespaceLabel: "shared"}})
+
+				resource := reconcileResource()
+
+				Expect(fake.Token()).To(Equal("shared-token"))
+				Expect(resource.Status.Number).To(Equal(1))
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionForbidden)).To(BeTrue())
+			})
+
+			It("should report Forbidden when the namespace is not selected", func() {
+				createCredentials(&metav1.LabelSelector{MatchLabels: map[string]string{namespaceLabel: "shared"}})
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionForbidden)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal("NamespaceNotAllowed"))
+				Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+				Expect(fake.Calls("Create")).To(BeZero())
+			})
+
+			It("should report Forbidden when no namespaces are allowed", func() {
+				setNamespaceLabel("shared")
+				createCredentials(nil)
+
+				resource := reconcileResource()
+
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionForbidden)).To(BeTrue())
+				Expect(fake.Calls("Create")).To(BeZero())
+			})
+
+			It("should report AuthFailed when the GithubCredentials do not exist", func() {
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring(`githubcredentials "shared-token" not found`))
+			})
+		})
+
+		Context("with a Secret for GitHub Enterprise Server", func() {
+			const secretName = "github-enterprise"
+
+			createSecret := func(data map[string]string) {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+					Data:       map[string][]byte{},
+				}
+				for key, value := range data {
+					secret.Data[key] = []byte(value)
+				}
+				Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+				})
+			}
+
+			It("should use the API URL and CA bundle of the Secret", func() {
+				configMap := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "ghe-ca", Namespace: "default"},
+					Data:       map[string]string{"ca.crt": "bundle"},
+				}
+				Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, configMap))).To(Succeed())
+				})
+				createSecret(map[string]string{
+					"token":        "ghe-token",
+					"api-url":      "https://ghe.example.com/api/v3",
+					"ca-configmap": "ghe-ca",
+				})
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "token"})
+
+				reconcileResource()
+
+				Expect(fake.Credentials()).To(Equal(github.Credentials{
+					Token:    "ghe-token",
+					BaseURL:  "https://ghe.example.com/api/v3",
+					CABundle: []byte("bundle"),
+				}))
+			})
+
+			It("should report AuthFailed for an invalid API URL", func() {
+				createSecret(map[string]string{"token": "ghe-token", "api-url": "ghe.example.com/api/v3"})
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "token"})
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring("invalid api-url"))
+			})
+
+			It("should report AuthFailed when the CA ConfigMap does not exist", func() {
+				createSecret(map[string]string{"token": "ghe-token", "ca-configmap": "missing-ca"})
+				setTokenSecretRef(&danaiov1alpha1.SecretKeyReference{Name: secretName, Key: "token"})
+
+				resource := reconcileResource()
+
+				condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionAuthFailed)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Message).To(ContainSubstring(`configmap "missing-ca" not found`))
+			})
 		})
 	})
 })
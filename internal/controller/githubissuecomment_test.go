@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("GithubIssueComment", func() {
+	const issueName = "commented-issue"
+	const commentName = "status-update"
+	const repo = "TalDebi/GithubIssue"
+
+	ctx := context.Background()
+
+	issueKey := types.NamespacedName{Name: issueName, Namespace: "default"}
+	commentKey := types.NamespacedName{Name: commentName, Namespace: "default"}
+	var fake *github.FakeClient
+	var controllerReconciler *GithubIssueReconciler
+
+	BeforeEach(func() {
+		fake = github.NewFakeClient()
+		controllerReconciler = &GithubIssueReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			GitHub:    fake,
+			ClusterID: "test-cluster",
+		}
+
+		Expect(k8sClient.Create(ctx, &danaiov1alpha1.GithubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: issueName, Namespace: "default"},
+			Spec:       danaiov1alpha1.GithubIssueSpec{Repo: repo, Title: "Commented issue"},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &danaiov1alpha1.GithubIssueComment{
+			ObjectMeta: metav1.ObjectMeta{Name: commentName, Namespace: "default"},
+			Spec: danaiov1alpha1.GithubIssueCommentSpec{
+				IssueRef: danaiov1alpha1.LocalIssueReference{Name: issueName},
+				Body:     "Work has started",
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		for _, obj := range []client.Object{&danaiov1alpha1.GithubIssueComment{}, &danaiov1alpha1.GithubIssue{}} {
+			key := commentKey
+			if _, ok := obj.(*danaiov1alpha1.GithubIssue); ok {
+				key = issueKey
+			}
+			if err := k8sClient.Get(ctx, key, obj); errors.IsNotFound(err) {
+				continue
+			}
+			obj.SetFinalizers(nil)
+			Expect(k8sClient.Update(ctx, obj)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+		}
+	})
+
+	reconcileIssue := func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: issueKey})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getComment := func() *danaiov1alpha1.GithubIssueComment {
+		comment := &danaiov1alpha1.GithubIssueComment{}
+		Expect(k8sClient.Get(ctx, commentKey, comment)).To(Succeed())
+		return comment
+	}
+
+	It("should post the comment on the issue and record it in status", func() {
+		reconcileIssue()
+
+		comments := fake.Comments(repo, 1)
+		Expect(comments).To(HaveLen(1))
+		Expect(github.StripMarker(comments[0].Body)).To(Equal("Work has started"))
+
+		comment := getComment()
+		Expect(comment.Status.Repo).To(Equal(repo))
+		Expect(comment.Status.IssueNumber).To(Equal(1))
+		Expect(comment.Status.CommentID).To(Equal(comments[0].ID))
+		Expect(comment.Status.URL).To(Equal(comments[0].HTMLURL))
+		Expect(meta.IsStatusConditionTrue(comment.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		Expect(controllerutil.ContainsFinalizer(comment, githubIssueCommentFinalizer)).To(BeTrue())
+		Expect(comment.OwnerReferences).To(HaveLen(1))
+		Expect(comment.OwnerReferences[0].Name).To(Equal(issueName))
+
+		By("reconciling again without posting a second comment")
+		reconcileIssue()
+		Expect(fake.Comments(repo, 1)).To(HaveLen(1))
+	})
+
+	It("should edit the comment when the body changes", func() {
+		reconcileIssue()
+		comment := getComment()
+		comment.Spec.Body = "Work is done"
+		Expect(k8sClient.Update(ctx, comment)).To(Succeed())
+
+		reconcileIssue()
+
+		comments := fake.Comments(repo, 1)
+		Expect(comments).To(HaveLen(1))
+		Expect(github.StripMarker(comments[0].Body)).To(Equal("Work is done"))
+		Expect(fake.Calls("UpdateComment")).To(Equal(1))
+	})
+
+	It("should find the comment by its marker when the status was lost", func() {
+		reconcileIssue()
+		comment := getComment()
+		comment.Status = danaiov1alpha1.GithubIssueCommentStatus{}
+		Expect(k8sClient.Status().Update(ctx, comment)).To(Succeed())
+
+		reconcileIssue()
+
+		Expect(fake.Comments(repo, 1)).To(HaveLen(1))
+		Expect(getComment().Status.CommentID).To(Equal(fake.Comments(repo, 1)[0].ID))
+	})
+
+	It("should post the comment again when it was deleted on GitHub", func() {
+		reconcileIssue()
+		Expect(fake.DeleteComment(ctx, repo, getComment().Status.CommentID)).To(Succeed())
+
+		reconcileIssue()
+
+		Expect(fake.Comments(repo, 1)).To(HaveLen(1))
+		Expect(getComment().Status.CommentID).To(Equal(fake.Comments(repo, 1)[0].ID))
+	})
+
+	It("should delete the comment from GitHub when the GithubIssueComment is deleted", func() {
+		reconcileIssue()
+		Expect(k8sClient.Delete(ctx, getComment())).To(Succeed())
+
+		reconcileIssue()
+
+		Expect(fake.Comments(repo, 1)).To(BeEmpty())
+		err := k8sClient.Get(ctx, commentKey, &danaiov1alpha1.GithubIssueComment{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should release the comments when the GithubIssue is deleted", func() {
+		reconcileIssue()
+		issue := &danaiov1alpha1.GithubIssue{}
+		Expect(k8sClient.Get(ctx, issueKey, issue)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, issue)).To(Succeed())
+
+		reconcileIssue()
+
+		Expect(fake.Comments(repo, 1)).To(HaveLen(1))
+		Expect(getComment().Finalizers).To(BeEmpty())
+	})
+
+	It("should record the failure on the comment", func() {
+		reconcileIssue()
+		comment := getComment()
+		comment.Spec.Body = "Work is done"
+		Expect(k8sClient.Update(ctx, comment)).To(Succeed())
+		fake.SetWriteError(errors.NewBadRequest("injected failure"))
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: issueKey})
+		Expect(err).To(HaveOccurred())
+
+		condition := meta.FindStatusCondition(getComment().Status.Conditions, danaiov1alpha1.ConditionSynced)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("injected failure"))
+	})
+})
@@ -0,0 +1,301 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// githubLabelFinalizer guards the upstream label until the deletion policy has been applied.
+const githubLabelFinalizer = "dana.io/githublabel-finalizer"
+
+// GithubLabelReconciler reconciles a GithubLabel object
+type GithubLabelReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// GitHub creates the clients managing the upstream labels.
+	GitHub github.ClientFactory
+	// DefaultCredentials are used for GithubLabels that do not reference credentials of their own.
+	DefaultCredentials github.Credentials
+	// OperatorNamespace is the namespace holding the Secrets of GithubCredentials.
+	OperatorNamespace string
+}
+
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githublabels,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githublabels/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githublabels/finalizers,verbs=update
+
+// Reconcile makes sure the repository of the GithubLabel has a label matching
+// its spec. Missing labels are created; a label of the same name that exists
+// already is only taken over when the spec adopts it, and reported through the
+// Conflict condition otherwise. Once created or adopted the label is owned:
+// its name, color and description are kept in line with the spec, and the
+// spec's DeletionPolicy is applied to it when the GithubLabel is deleted. An
+// owned label is not moved to another repository; the move is reported
+// through the Conflict condition.
+func (r *GithubLabelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	label := &danaiov1alpha1.GithubLabel{}
+	if err := r.Get(ctx, req.NamespacedName, label); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !label.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, label); err != nil {
+			return r.syncFailed(ctx, label, "DeletionFailed", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(label, githubLabelFinalizer) {
+		if err := r.Update(ctx, label); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	repo, gh, err := r.labelClient(ctx, label)
+	if err != nil {
+		return r.syncFailed(ctx, label, "SyncFailed", err)
+	}
+	if owner := label.Status.Repo; owner != "" && owner != repo {
+		// The credentials the owned label was managed with are not known
+		// anymore, so it is neither cleaned up nor moved.
+		return r.syncFailed(ctx, label, "SyncFailed", &conflictError{"RepositoryChanged", fmt.Sprintf(
+			"label %q is owned in %s; delete the GithubLabel and create it again to move it to %s",
+			label.Status.Name, owner, repo)})
+	}
+	if err := r.syncLabel(ctx, gh, label, repo); err != nil {
+		return r.syncFailed(ctx, label, "SyncFailed", err)
+	}
+
+	label.Status.Repo = repo
+	label.Status.Name = label.Spec.Name
+	label.Status.ObservedGeneration = label.Generation
+	r.setSyncedConditions(label)
+	return ctrl.Result{RequeueAfter: resyncPeriod}, r.updateStatus(ctx, label)
+}
+
+// syncLabel creates, adopts or updates the upstream label. A label recorded in
+// status is looked up by its recorded name so that renames are applied to it;
+// when it was deleted on GitHub it is created again.
+func (r *GithubLabelReconciler) syncLabel(ctx context.Context, gh github.IssueClient,
+	label *danaiov1alpha1.GithubLabel, repo string) error {
+	desired := github.Label{Name: label.Spec.Name, Color: labelColor(label.Spec), Description: label.Spec.Description}
+
+	var current *github.Label
+	var err error
+	if label.Status.Name != "" {
+		current, err = gh.GetLabel(ctx, repo, label.Status.Name)
+		if err != nil && !github.IsNotFound(err) {
+			return err
+		}
+	}
+	if current == nil {
+		current, err = gh.GetLabel(ctx, repo, label.Spec.Name)
+		if github.IsNotFound(err) {
+			log.FromContext(ctx).Info("creating GitHub label", "repo", repo, "label", desired.Name)
+			_, err = gh.CreateLabel(ctx, repo, desired)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if label.Status.Name == "" && !label.Spec.Adopt {
+			return &conflictError{"LabelExists",
+				fmt.Sprintf("label %q already exists in %s; set adopt to take it over", current.Name, repo)}
+		}
+	}
+
+	if labelMatches(current, desired) {
+		return nil
+	}
+	log.FromContext(ctx).Info("updating GitHub label", "repo", repo, "label", current.Name)
+	_, err = gh.UpdateLabel(ctx, repo, current.Name, desired)
+	return err
+}
+
+// labelMatches compares an upstream label with the desired one. GitHub reports
+// colors in lower case.
+func labelMatches(current *github.Label, desired github.Label) bool {
+	return current.Name == desired.Name && strings.EqualFold(current.Color, desired.Color) &&
+		current.Description == desired.Description
+}
+
+// labelColor returns the color requested by the spec in the lower case GitHub uses.
+func labelColor(spec danaiov1alpha1.GithubLabelSpec) string {
+	if spec.Color == "" {
+		return danaiov1alpha1.DefaultLabelColor
+	}
+	return strings.ToLower(spec.Color)
+}
+
+// labelClient returns the repository of the GithubLabel and a GitHub client
+// authenticated with the credentials it references, directly or through its
+// GithubRepository.
+func (r *GithubLabelReconciler) labelClient(ctx context.Context,
+	label *danaiov1alpha1.GithubLabel) (string, github.IssueClient, error) {
+	spec := label.Spec
+	loader := &credentialsLoader{Reader: r.Client, defaults: r.DefaultCredentials,
+		operatorNamespace: r.OperatorNamespace}
+	return loader.repositoryClient(ctx, r.GitHub, label.Namespace, repositoryTarget{repo: spec.Repo,
+		repositoryRef: spec.RepositoryRef, tokenRef: spec.TokenSecretRef, appRef: spec.AppSecretRef,
+		credentialsRef: spec.CredentialsRef})
+}
+
+// finalize applies the deletion policy to the owned label and releases the
+// finalizer. The finalizer stays in place until GitHub accepted the change,
+// unless the repository or credentials of the label are gone.
+func (r *GithubLabelReconciler) finalize(ctx context.Context, label *danaiov1alpha1.GithubLabel) error {
+	if !controllerutil.ContainsFinalizer(label, githubLabelFinalizer) {
+		return nil
+	}
+
+	if label.Status.Name != "" && label.Spec.DeletionPolicy != danaiov1alpha1.LabelDeletionPolicyOrphan {
+		repo, gh, err := r.labelClient(ctx, label)
+		if err == nil && repo != label.Status.Repo {
+			err = &repositoryError{fmt.Sprintf("the GithubLabel was moved away from %s", label.Status.Repo)}
+		}
+		if err == nil {
+			err = r.applyDeletionPolicy(ctx, gh, label)
+		}
+		if err := r.skipDeletionPolicy(ctx, label, err); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(label, githubLabelFinalizer)
+	return r.Update(ctx, label)
+}
+
+// skipDeletionPolicy returns err when it may go away by retrying. Errors
+// caused by a repository or credentials that are gone or refused are recorded
+// in the status conditions instead, and nil is returned so that the finalizer
+// is released.
+func (r *GithubLabelReconciler) skipDeletionPolicy(ctx context.Context, label *danaiov1alpha1.GithubLabel,
+	err error) error {
+	if err == nil || !deletionPolicyUnreachable(err) {
+		return err
+	}
+
+	log.FromContext(ctx).Info("leaving GitHub label untouched, its repository or credentials are gone",
+		"repo", label.Status.Repo, "label", label.Status.Name, "reason", err.Error())
+	meta.SetStatusCondition(&label.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse, Reason: "DeletionPolicySkipped",
+		Message: "deletion policy not applied to the label: " + err.Error(), ObservedGeneration: label.Generation})
+	return client.IgnoreNotFound(r.Status().Update(ctx, label))
+}
+
+// applyDeletionPolicy deletes the label recorded in status unless the policy
+// orphans it. Labels already deleted on GitHub are skipped.
+func (r *GithubLabelReconciler) applyDeletionPolicy(ctx context.Context, gh github.IssueClient,
+	label *danaiov1alpha1.GithubLabel) error {
+	if label.Status.Name == "" || label.Spec.DeletionPolicy == danaiov1alpha1.LabelDeletionPolicyOrphan {
+		return nil
+	}
+	log.FromContext(ctx).Info("deleting GitHub label", "repo", label.Status.Repo, "label", label.Status.Name)
+	if err := gh.DeleteLabel(ctx, label.Status.Repo, label.Status.Name); err != nil && !github.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// syncFailed records a failed sync in the status conditions, see syncFailure.
+func (r *GithubLabelReconciler) syncFailed(ctx context.Context, label *danaiov1alpha1.GithubLabel,
+	reason string, err error) (ctrl.Result, error) {
+	reason, result, retErr := syncFailure(err, reason)
+	if retErr != nil {
+		log.FromContext(ctx).Error(err, "failed to sync label with GitHub", "label", label.Spec.Name)
+	}
+	meta.SetStatusCondition(&label.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse, Reason: reason, Message: err.Error(), ObservedGeneration: label.Generation})
+	var conflictErr *conflictError
+	if errors.As(err, &conflictErr) {
+		meta.SetStatusCondition(&label.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionConflict,
+			Status: metav1.ConditionTrue, Reason: reason, Message: err.Error(), ObservedGeneration: label.Generation})
+	}
+
+	if statusErr := r.updateStatus(ctx, label); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update GithubLabel status")
+	}
+	return result, retErr
+}
+
+// updateStatus writes the status of label unless it is unchanged, so that
+// periodic resyncs of an in-sync label do not trigger another reconcile.
+func (r *GithubLabelReconciler) updateStatus(ctx context.Context, label *danaiov1alpha1.GithubLabel) error {
+	current := &danaiov1alpha1.GithubLabel{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(label), current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if equality.Semantic.DeepEqual(current.Status, label.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, label)
+}
+
+func (r *GithubLabelReconciler) setSyncedConditions(label *danaiov1alpha1.GithubLabel) {
+	conditions := &label.Status.Conditions
+	generation := label.Generation
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "label matches the spec",
+		ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionConflict,
+		Status: metav1.ConditionFalse, Reason: "Owned", ObservedGeneration: generation})
+}
+
+// requestsForRepository enqueues the GithubLabels that reference the GithubRepository.
+func (r *GithubLabelReconciler) requestsForRepository(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := &danaiov1alpha1.GithubLabelList{}
+	if err := r.List(ctx, labels, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubLabels")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, label := range labels.Items {
+		if ref := label.Spec.RepositoryRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&label)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GithubLabelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.GithubLabel{}).
+		Watches(&danaiov1alpha1.GithubRepository{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRepository)).
+		Named("githublabel").
+		Complete(r)
+}
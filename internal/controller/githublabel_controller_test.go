@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("GithubLabel Controller", func() {
+	const resourceName = "triage-label"
+	const repo = "TalDebi/GithubIssue"
+
+	ctx := context.Background()
+
+	typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	var fake *github.FakeClient
+	var controllerReconciler *GithubLabelReconciler
+
+	BeforeEach(func() {
+		fake = github.NewFakeClient()
+		controllerReconciler = &GithubLabelReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			GitHub: fake,
+		}
+
+		Expect(k8sClient.Create(ctx, &danaiov1alpha1.GithubLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: danaiov1alpha1.GithubLabelSpec{
+				Repo:        repo,
+				Name:        "triage",
+				Color:       "FBCA04",
+				Description: "Needs a first look",
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		resource := &danaiov1alpha1.GithubLabel{}
+		err := k8sClient.Get(ctx, typeNamespacedName, resource)
+		if errors.IsNotFound(err) {
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		if len(resource.Finalizers) > 0 {
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+		}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+	})
+
+	reconcileResource := func() *danaiov1alpha1.GithubLabel {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		resource := &danaiov1alpha1.GithubLabel{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		return resource
+	}
+
+	updateSpec := func(update func(spec *danaiov1alpha1.GithubLabelSpec)) {
+		resource := &danaiov1alpha1.GithubLabel{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		update(&resource.Spec)
+		Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+	}
+
+	It("should create a missing label and record it in status", func() {
+		resource := reconcileResource()
+
+		Expect(fake.Labels(repo)).To(ConsistOf(
+			github.Label{Name: "triage", Color: "fbca04", Description: "Needs a first look"}))
+		Expect(resource.Status.Repo).To(Equal(repo))
+		Expect(resource.Status.Name).To(Equal("triage"))
+		Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		Expect(controllerutil.ContainsFinalizer(resource, githubLabelFinalizer)).To(BeTrue())
+
+		By("reconciling again without touching the label")
+		reconcileResource()
+		Expect(fake.Calls("CreateLabel")).To(Equal(1))
+		Expect(fake.Calls("UpdateLabel")).To(BeZero())
+	})
+
+	It("should rename and recolor the label when the spec changes", func() {
+		reconcileResource()
+		updateSpec(func(spec *danaiov1alpha1.GithubLabelSpec) {
+			spec.Name = "needs triage"
+			spec.Color = "d73a4a"
+			spec.Description = ""
+		})
+
+		resource := reconcileResource()
+
+		Expect(fake.Labels(repo)).To(ConsistOf(github.Label{Name: "needs triage", Color: "d73a4a"}))
+		Expect(resource.Status.Name).To(Equal("needs triage"))
+	})
+
+	It("should create the label again when it was deleted on GitHub", func() {
+		reconcileResource()
+		Expect(fake.DeleteLabel(ctx, repo, "triage")).To(Succeed())
+
+		reconcileResource()
+
+		Expect(fake.Labels(repo)).To(HaveLen(1))
+		Expect(fake.Calls("CreateLabel")).To(Equal(2))
+	})
+
+	It("should report a conflict with a label created outside Kubernetes", func() {
+		existing := github.Label{Name: "Triage", Color: "ededed", Description: "Owned by someone else"}
+		fake.AddLabel(repo, existing)
+
+		resource := reconcileResource()
+
+		Expect(fake.Labels(repo)).To(ConsistOf(existing))
+		Expect(resource.Status.Name).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+
+		By("adopting the label once asked to")
+		updateSpec(func(spec *danaiov1alpha1.GithubLabelSpec) { spec.Adopt = true })
+		resource = reconcileResource()
+
+		Expect(fake.Labels(repo)).To(ConsistOf(
+			github.Label{Name: "triage", Color: "fbca04", Description: "Needs a first look"}))
+		Expect(resource.Status.Name).To(Equal("triage"))
+		Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)).To(BeTrue())
+	})
+
+	deleteResource := func() {
+		resource := &danaiov1alpha1.GithubLabel{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+	}
+
+	It("should delete the label when the GithubLabel is deleted", func() {
+		reconcileResource()
+
+		deleteResource()
+
+		Expect(fake.Labels(repo)).To(BeEmpty())
+	})
+
+	It("should leave the label on GitHub with the Orphan policy", func() {
+		updateSpec(func(spec *danaiov1alpha1.GithubLabelSpec) {
+			spec.DeletionPolicy = danaiov1alpha1.LabelDeletionPolicyOrphan
+		})
+		reconcileResource()
+
+		deleteResource()
+
+		Expect(fake.Labels(repo)).To(HaveLen(1))
+		Expect(fake.Calls("DeleteLabel")).To(BeZero())
+	})
+
+	It("should refuse to move an owned label to another repository", func() {
+		reconcileResource()
+		updateSpec(func(spec *danaiov1alpha1.GithubLabelSpec) { spec.Repo = "TalDebi/operator" })
+
+		resource := reconcileResource()
+
+		Expect(fake.Labels(repo)).To(HaveLen(1))
+		Expect(fake.Labels("TalDebi/operator")).To(BeEmpty())
+		Expect(resource.Status.Repo).To(Equal(repo))
+		condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("RepositoryChanged"))
+
+		By("leaving the owned label alone when the moved GithubLabel is deleted")
+		deleteResource()
+		Expect(fake.Labels(repo)).To(HaveLen(1))
+		Expect(fake.Calls("DeleteLabel")).To(BeZero())
+	})
+
+	It("should release the finalizer when the GithubRepository is gone", func() {
+		repository := &danaiov1alpha1.GithubRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "label-repository", Namespace: "default"},
+			Spec:       danaiov1alpha1.GithubRepositorySpec{Owner: "TalDebi", Name: "operator"},
+		}
+		Expect(k8sClient.Create(ctx, repository)).To(Succeed())
+		updateSpec(func(spec *danaiov1alpha1.GithubLabelSpec) {
+			spec.Repo = ""
+			spec.RepositoryRef = &danaiov1alpha1.LocalRepositoryReference{Name: repository.Name}
+		})
+		Expect(reconcileResource().Status.Repo).To(Equal("TalDebi/operator"))
+		Expect(k8sClient.Delete(ctx, repository)).To(Succeed())
+
+		deleteResource()
+
+		Expect(fake.Labels("TalDebi/operator")).To(HaveLen(1))
+		Expect(fake.Calls("DeleteLabel")).To(BeZero())
+	})
+
+	It("should never delete a label it does not own", func() {
+		fake.AddLabel(repo, github.Label{Name: "triage"})
+		reconcileResource()
+
+		deleteResource()
+
+		Expect(fake.Labels(repo)).To(HaveLen(1))
+	})
+})
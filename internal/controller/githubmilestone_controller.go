@@ -0,0 +1,352 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// githubMilestoneFinalizer guards the upstream milestone until the deletion policy has been applied.
+const githubMilestoneFinalizer = "dana.io/githubmilestone-finalizer"
+
+// GithubMilestoneReconciler reconciles a GithubMilestone object
+type GithubMilestoneReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// GitHub creates the clients managing the upstream milestones.
+	GitHub github.ClientFactory
+	// DefaultCredentials are used for GithubMilestones that do not reference credentials of their own.
+	DefaultCredentials github.Credentials
+	// OperatorNamespace is the namespace holding the Secrets of GithubCredentials.
+	OperatorNamespace string
+}
+
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubmilestones,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubmilestones/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubmilestones/finalizers,verbs=update
+
+// Reconcile makes sure the repository of the GithubMilestone has a milestone
+// matching its spec, including its open or closed state. Missing milestones
+// are created; a milestone of the same title that exists already is only
+// taken over when the spec adopts it, and reported through the Conflict
+// condition otherwise. On deletion the spec's DeletionPolicy is applied to
+// the owned milestone before the finalizer is removed. An owned milestone is
+// not moved to another repository; the move is reported through the Conflict
+// condition.
+func (r *GithubMilestoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	milestone := &danaiov1alpha1.GithubMilestone{}
+	if err := r.Get(ctx, req.NamespacedName, milestone); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !milestone.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, milestone); err != nil {
+			return r.syncFailed(ctx, milestone, "DeletionFailed", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(milestone, githubMilestoneFinalizer) {
+		if err := r.Update(ctx, milestone); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	repo, gh, err := r.milestoneClient(ctx, milestone)
+	if err != nil {
+		return r.syncFailed(ctx, milestone, "SyncFailed", err)
+	}
+	if owner := milestone.Status.Repo; owner != "" && owner != repo {
+		// The credentials the owned milestone was managed with are not known
+		// anymore, so it is neither cleaned up nor moved.
+		return r.syncFailed(ctx, milestone, "SyncFailed", &conflictError{"RepositoryChanged", fmt.Sprintf(
+			"milestone %d is owned in %s; delete the GithubMilestone and create it again to move it to %s",
+			milestone.Status.Number, owner, repo)})
+	}
+	upstream, err := r.syncMilestone(ctx, gh, milestone, repo)
+	if err != nil {
+		return r.syncFailed(ctx, milestone, "SyncFailed", err)
+	}
+
+	milestone.Status.Repo = repo
+	milestone.Status.Number = upstream.Number
+	milestone.Status.URL = upstream.HTMLURL
+	milestone.Status.State = danaiov1alpha1.MilestoneState(upstream.State)
+	milestone.Status.ObservedGeneration = milestone.Generation
+	r.setSyncedConditions(milestone)
+	return ctrl.Result{RequeueAfter: resyncPeriod}, r.updateStatus(ctx, milestone)
+}
+
+// syncMilestone creates, adopts or updates the upstream milestone. A milestone
+// recorded in status is looked up by its number so that title changes are
+// applied to it; when it was deleted on GitHub it is created again.
+func (r *GithubMilestoneReconciler) syncMilestone(ctx context.Context, gh github.IssueClient,
+	milestone *danaiov1alpha1.GithubMilestone, repo string) (*github.Milestone, error) {
+	desired := desiredMilestone(milestone.Spec)
+
+	var current *github.Milestone
+	var err error
+	if number := milestone.Status.Number; number != 0 {
+		current, err = gh.GetMilestone(ctx, repo, number)
+		if err != nil && !github.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if current == nil {
+		current, err = findMilestone(ctx, gh, repo, milestone.Spec.Title)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			log.FromContext(ctx).Info("creating GitHub milestone", "repo", repo, "title", desired.Title)
+			return gh.CreateMilestone(ctx, repo, desired)
+		}
+		if milestone.Status.Number == 0 && !milestone.Spec.Adopt {
+			return nil, &conflictError{"MilestoneExists",
+				fmt.Sprintf("milestone %q already exists in %s; set adopt to take it over", current.Title, repo)}
+		}
+	}
+
+	if milestoneMatches(current, desired) {
+		return current, nil
+	}
+	log.FromContext(ctx).Info("updating GitHub milestone", "repo", repo, "number", current.Number)
+	return gh.UpdateMilestone(ctx, repo, current.Number, desired)
+}
+
+// findMilestone returns the open or closed milestone with the given title, or nil if there is none.
+func findMilestone(ctx context.Context, gh github.IssueClient, repo, title string) (*github.Milestone, error) {
+	milestones, err := gh.ListMilestones(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, milestone := range milestones {
+		if milestone.Title == title {
+			return &milestone, nil
+		}
+	}
+	return nil, nil
+}
+
+// desiredMilestone returns the milestone requested by the spec, open unless
+// the spec closes it.
+func desiredMilestone(spec danaiov1alpha1.GithubMilestoneSpec) github.MilestoneRequest {
+	desired := github.MilestoneRequest{
+		Title:       spec.Title,
+		State:       string(spec.State),
+		Description: spec.Description,
+	}
+	if desired.State == "" {
+		desired.State = github.StateOpen
+	}
+	if spec.DueOn != nil {
+		dueOn := spec.DueOn.UTC()
+		desired.DueOn = &dueOn
+	}
+	return desired
+}
+
+// milestoneMatches compares an upstream milestone with the desired one. Due
+// dates are compared by day, as GitHub does not keep their time.
+func milestoneMatches(current *github.Milestone, desired github.MilestoneRequest) bool {
+	if current.Title != desired.Title || current.Description != desired.Description || current.State != desired.State {
+		return false
+	}
+	if current.DueOn == nil || desired.DueOn == nil {
+		return current.DueOn == nil && desired.DueOn == nil
+	}
+	const day = time.DateOnly
+	return current.DueOn.UTC().Format(day) == desired.DueOn.UTC().Format(day)
+}
+
+// milestoneClient returns the repository of the GithubMilestone and a GitHub
+// client authenticated with the credentials it references, directly or
+// through its GithubRepository.
+func (r *GithubMilestoneReconciler) milestoneClient(ctx context.Context,
+	milestone *danaiov1alpha1.GithubMilestone) (string, github.IssueClient, error) {
+	spec := milestone.Spec
+	loader := &credentialsLoader{Reader: r.Client, defaults: r.DefaultCredentials,
+		operatorNamespace: r.OperatorNamespace}
+	return loader.repositoryClient(ctx, r.GitHub, milestone.Namespace, repositoryTarget{repo: spec.Repo,
+		repositoryRef: spec.RepositoryRef, tokenRef: spec.TokenSecretRef, appRef: spec.AppSecretRef,
+		credentialsRef: spec.CredentialsRef})
+}
+
+// finalize applies the deletion policy to the owned milestone and releases
+// the finalizer. The finalizer stays in place until GitHub accepted the
+// change, unless the repository or credentials of the milestone are gone.
+func (r *GithubMilestoneReconciler) finalize(ctx context.Context, milestone *danaiov1alpha1.GithubMilestone) error {
+	if !controllerutil.ContainsFinalizer(milestone, githubMilestoneFinalizer) {
+		return nil
+	}
+
+	if milestone.Status.Number != 0 && milestone.Spec.DeletionPolicy != danaiov1alpha1.MilestoneDeletionPolicyOrphan {
+		repo, gh, err := r.milestoneClient(ctx, milestone)
+		if err == nil && repo != milestone.Status.Repo {
+			err = &repositoryError{fmt.Sprintf("the GithubMilestone was moved away from %s", milestone.Status.Repo)}
+		}
+		if err == nil {
+			err = r.applyDeletionPolicy(ctx, gh, milestone)
+		}
+		if err := r.skipDeletionPolicy(ctx, milestone, err); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(milestone, githubMilestoneFinalizer)
+	return r.Update(ctx, milestone)
+}
+
+// skipDeletionPolicy returns err when it may go away by retrying. Errors
+// caused by a repository or credentials that are gone or refused are recorded
+// in the status conditions instead, and nil is returned so that the finalizer
+// is released.
+func (r *GithubMilestoneReconciler) skipDeletionPolicy(ctx context.Context,
+	milestone *danaiov1alpha1.GithubMilestone, err error) error {
+	if err == nil || !deletionPolicyUnreachable(err) {
+		return err
+	}
+
+	log.FromContext(ctx).Info("leaving GitHub milestone untouched, its repository or credentials are gone",
+		"repo", milestone.Status.Repo, "number", milestone.Status.Number, "reason", err.Error())
+	meta.SetStatusCondition(&milestone.Status.Conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse, Reason: "DeletionPolicySkipped",
+		Message: "deletion policy not applied to the milestone: " + err.Error(), ObservedGeneration: milestone.Generation})
+	return client.IgnoreNotFound(r.Status().Update(ctx, milestone))
+}
+
+// applyDeletionPolicy closes or deletes the milestone recorded in status.
+// Milestones already deleted on GitHub are skipped.
+func (r *GithubMilestoneReconciler) applyDeletionPolicy(ctx context.Context, gh github.IssueClient,
+	milestone *danaiov1alpha1.GithubMilestone) error {
+	repo, number := milestone.Status.Repo, milestone.Status.Number
+	if number == 0 {
+		return nil
+	}
+
+	switch milestone.Spec.DeletionPolicy {
+	case danaiov1alpha1.MilestoneDeletionPolicyOrphan:
+		return nil
+	case danaiov1alpha1.MilestoneDeletionPolicyDelete:
+		log.FromContext(ctx).Info("deleting GitHub milestone", "repo", repo, "number", number)
+		if err := gh.DeleteMilestone(ctx, repo, number); err != nil && !github.IsNotFound(err) {
+			return err
+		}
+		return nil
+	default:
+		current, err := gh.GetMilestone(ctx, repo, number)
+		if github.IsNotFound(err) || (err == nil && current.State == github.StateClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.FromContext(ctx).Info("closing GitHub milestone", "repo", repo, "number", number)
+		_, err = gh.UpdateMilestone(ctx, repo, number, github.MilestoneRequest{Title: current.Title,
+			State: github.StateClosed, Description: current.Description, DueOn: current.DueOn})
+		return err
+	}
+}
+
+// syncFailed records a failed sync in the status conditions, see syncFailure.
+func (r *GithubMilestoneReconciler) syncFailed(ctx context.Context, milestone *danaiov1alpha1.GithubMilestone,
+	reason string, err error) (ctrl.Result, error) {
+	reason, result, retErr := syncFailure(err, reason)
+	if retErr != nil {
+		log.FromContext(ctx).Error(err, "failed to sync milestone with GitHub", "title", milestone.Spec.Title)
+	}
+	conditions := &milestone.Status.Conditions
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse, Reason: reason, Message: err.Error(), ObservedGeneration: milestone.Generation})
+	var conflictErr *conflictError
+	if errors.As(err, &conflictErr) {
+		meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionConflict,
+			Status: metav1.ConditionTrue, Reason: reason, Message: err.Error(), ObservedGeneration: milestone.Generation})
+	}
+
+	if statusErr := r.updateStatus(ctx, milestone); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update GithubMilestone status")
+	}
+	return result, retErr
+}
+
+// updateStatus writes the status of milestone unless it is unchanged, so that
+// periodic resyncs of an in-sync milestone do not trigger another reconcile.
+func (r *GithubMilestoneReconciler) updateStatus(ctx context.Context, milestone *danaiov1alpha1.GithubMilestone) error {
+	current := &danaiov1alpha1.GithubMilestone{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(milestone), current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if equality.Semantic.DeepEqual(current.Status, milestone.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, milestone)
+}
+
+func (r *GithubMilestoneReconciler) setSyncedConditions(milestone *danaiov1alpha1.GithubMilestone) {
+	conditions := &milestone.Status.Conditions
+	generation := milestone.Generation
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionReady,
+		Status: metav1.ConditionTrue, Reason: "Synced", Message: "milestone matches the spec",
+		ObservedGeneration: generation})
+	meta.SetStatusCondition(conditions, metav1.Condition{Type: danaiov1alpha1.ConditionConflict,
+		Status: metav1.ConditionFalse, Reason: "Owned", ObservedGeneration: generation})
+}
+
+// requestsForRepository enqueues the GithubMilestones that reference the GithubRepository.
+func (r *GithubMilestoneReconciler) requestsForRepository(ctx context.Context, obj client.Object) []reconcile.Request {
+	milestones := &danaiov1alpha1.GithubMilestoneList{}
+	if err := r.List(ctx, milestones, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubMilestones")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, milestone := range milestones.Items {
+		if ref := milestone.Spec.RepositoryRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&milestone)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GithubMilestoneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&danaiov1alpha1.GithubMilestone{}).
+		Watches(&danaiov1alpha1.GithubRepository{}, handler.EnqueueRequestsFromMapFunc(r.requestsForRepository)).
+		Named("githubmilestone").
+		Complete(r)
+}
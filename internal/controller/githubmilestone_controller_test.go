@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("GithubMilestone Controller", func() {
+	const resourceName = "first-release"
+	const repo = "TalDebi/GithubIssue"
+
+	ctx := context.Background()
+	dueOn := metav1.NewTime(time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC))
+
+	typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	var fake *github.FakeClient
+	var controllerReconciler *GithubMilestoneReconciler
+
+	BeforeEach(func() {
+		fake = github.NewFakeClient()
+		controllerReconciler = &GithubMilestoneReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			GitHub: fake,
+		}
+
+		Expect(k8sClient.Create(ctx, &danaiov1alpha1.GithubMilestone{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec: danaiov1alpha1.GithubMilestoneSpec{
+				Repo:        repo,
+				Title:       "v1.0",
+				Description: "First stable release",
+				DueOn:       &dueOn,
+			},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		resource := &danaiov1alpha1.GithubMilestone{}
+		err := k8sClient.Get(ctx, typeNamespacedName, resource)
+		if errors.IsNotFound(err) {
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		if len(resource.Finalizers) > 0 {
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+		}
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+	})
+
+	reconcileResource := func() *danaiov1alpha1.GithubMilestone {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		resource := &danaiov1alpha1.GithubMilestone{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		return resource
+	}
+
+	updateSpec := func(update func(spec *danaiov1alpha1.GithubMilestoneSpec)) {
+		resource := &danaiov1alpha1.GithubMilestone{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		update(&resource.Spec)
+		Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+	}
+
+	It("should create a missing milestone and record it in status", func() {
+		resource := reconcileResource()
+
+		milestone := fake.Milestone(repo, 1)
+		Expect(milestone).NotTo(BeNil())
+		Expect(milestone.Title).To(Equal("v1.0"))
+		Expect(milestone.Description).To(Equal("First stable release"))
+		Expect(milestone.State).To(Equal(github.StateOpen))
+		Expect(milestone.DueOn).To(HaveValue(BeTemporally("==", dueOn.Time)))
+		Expect(resource.Status.Repo).To(Equal(repo))
+		Expect(resource.Status.Number).To(Equal(1))
+		Expect(resource.Status.URL).To(Equal(milestone.HTMLURL))
+		Expect(resource.Status.State).To(Equal(danaiov1alpha1.MilestoneStateOpen))
+		Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionReady)).To(BeTrue())
+		Expect(controllerutil.ContainsFinalizer(resource, githubMilestoneFinalizer)).To(BeTrue())
+
+		By("reconciling again without touching the milestone")
+		reconcileResource()
+		Expect(fake.Calls("CreateMilestone")).To(Equal(1))
+		Expect(fake.Calls("UpdateMilestone")).To(BeZero())
+	})
+
+	It("should ignore the time of day GitHub reports for the due date", func() {
+		reconcileResource()
+		shifted := dueOn.Add(7 * time.Hour)
+		_, err := fake.UpdateMilestone(ctx, repo, 1, github.MilestoneRequest{
+			Title: "v1.0", Description: "First stable release", DueOn: &shifted})
+		Expect(err).NotTo(HaveOccurred())
+		updates := fake.Calls("UpdateMilestone")
+
+		reconcileResource()
+
+		Expect(fake.Calls("UpdateMilestone")).To(Equal(updates))
+	})
+
+	It("should close the milestone on GitHub when the spec closes it", func() {
+		reconcileResource()
+		updateSpec(func(spec *danaiov1alpha1.GithubMilestoneSpec) {
+			spec.State = danaiov1alpha1.MilestoneStateClosed
+			spec.Title = "v1.0.0"
+		})
+
+		resource := reconcileResource()
+
+		milestone := fake.Milestone(repo, 1)
+		Expect(milestone.State).To(Equal(github.StateClosed))
+		Expect(milestone.Title).To(Equal("v1.0.0"))
+		Expect(resource.Status.State).To(Equal(danaiov1alpha1.MilestoneStateClosed))
+	})
+
+	It("should report a conflict with a milestone created outside Kubernetes", func() {
+		fake.AddMilestone(repo, "v1.0")
+
+		resource := reconcileResource()
+
+		Expect(fake.Calls("UpdateMilestone")).To(BeZero())
+		Expect(resource.Status.Number).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)).To(BeTrue())
+
+		By("adopting the milestone once asked to")
+		updateSpec(func(spec *danaiov1alpha1.GithubMilestoneSpec) { spec.Adopt = true })
+		resource = reconcileResource()
+
+		Expect(fake.Milestone(repo, 1).Description).To(Equal("First stable release"))
+		Expect(resource.Status.Number).To(Equal(1))
+		Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)).To(BeTrue())
+	})
+
+	deleteResource := func() {
+		resource := &danaiov1alpha1.GithubMilestone{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, typeNamespacedName, resource))).To(BeTrue())
+	}
+
+	It("should close the milestone when the GithubMilestone is deleted", func() {
+		reconcileResource()
+
+		deleteResource()
+
+		Expect(fake.Milestone(repo, 1).State).To(Equal(github.StateClosed))
+	})
+
+	It("should delete the milestone with the Delete policy", func() {
+		updateSpec(func(spec *danaiov1alpha1.GithubMilestoneSpec) {
+			spec.DeletionPolicy = danaiov1alpha1.MilestoneDeletionPolicyDelete
+		})
+		reconcileResource()
+
+		deleteResource()
+
+		Expect(fake.Milestone(repo, 1)).To(BeNil())
+	})
+
+	It("should refuse to move an owned milestone to another repository", func() {
+		reconcileResource()
+		updateSpec(func(spec *danaiov1alpha1.GithubMilestoneSpec) { spec.Repo = "TalDebi/operator" })
+
+		resource := reconcileResource()
+
+		Expect(fake.Milestone(repo, 1).State).To(Equal(github.StateOpen))
+		Expect(fake.Calls("CreateMilestone")).To(Equal(1))
+		Expect(resource.Status.Repo).To(Equal(repo))
+		condition := meta.FindStatusCondition(resource.Status.Conditions, danaiov1alpha1.ConditionConflict)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("RepositoryChanged"))
+
+		By("leaving the owned milestone alone when the moved GithubMilestone is deleted")
+		deleteResource()
+		Expect(fake.Milestone(repo, 1).State).To(Equal(github.StateOpen))
+	})
+
+	It("should release the finalizer when the token Secret is gone", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "milestone-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("milestone-token")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		updateSpec(func(spec *danaiov1alpha1.GithubMilestoneSpec) {
+			spec.TokenSecretRef = &danaiov1alpha1.SecretKeyReference{Name: secret.Name, Key: "token"}
+		})
+		Expect(reconcileResource().Status.Number).To(Equal(1))
+		Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+
+		deleteResource()
+
+		Expect(fake.Milestone(repo, 1).State).To(Equal(github.StateOpen))
+	})
+})
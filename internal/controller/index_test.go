@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldIndexes are the field indexes SetupWithManager registers on the cache
+// of the manager.
+var fieldIndexes = map[string]client.IndexerFunc{
+	credentialsSecretIndex:     indexCredentialsSecrets,
+	credentialsRefIndex:        indexCredentialsRef,
+	repositoryRefIndex:         indexRepositoryRef,
+	bodyTemplateConfigMapIndex: indexBodyTemplateConfigMap,
+	commentIssueRefIndex:       indexCommentIssueRef,
+}
+
+// indexedClient serves lists matching the fields of fieldIndexes, which the
+// API server does not know of, by filtering the objects on the client side
+// as the cache of the manager would.
+type indexedClient struct {
+	client.Client
+}
+
+func (c indexedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector == nil || listOpts.FieldSelector.Empty() {
+		return c.Client.List(ctx, list, opts...)
+	}
+	requirements := listOpts.FieldSelector.Requirements()
+	listOpts.FieldSelector = nil
+	if err := c.Client.List(ctx, list, listOpts); err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	var matching []runtime.Object
+	for _, item := range items {
+		matches := true
+		for _, requirement := range requirements {
+			index, ok := fieldIndexes[requirement.Field]
+			if !ok || requirement.Operator != selection.Equals && requirement.Operator != selection.DoubleEquals {
+				return fmt.Errorf("unsupported field selector %s %s %s",
+					requirement.Field, requirement.Operator, requirement.Value)
+			}
+			matches = matches && slices.Contains(index(item.(client.Object)), requirement.Value)
+		}
+		if matches {
+			matching = append(matching, item)
+		}
+	}
+	return meta.SetList(list, matching)
+}
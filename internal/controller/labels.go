@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// createMissingLabels creates the labels of the resolved spec that do not
+// exist in the repository yet, when the spec asks for it. Labels declared by
+// a GithubLabel in the namespace of githubIssue are left to that GithubLabel,
+// so that it does not find them taken.
+func (r *GithubIssueReconciler) createMissingLabels(ctx context.Context, gh github.IssueClient,
+	githubIssue *danaiov1alpha1.GithubIssue, spec danaiov1alpha1.GithubIssueSpec) error {
+	if !spec.CreateMissingLabels || len(spec.Labels) == 0 {
+		return nil
+	}
+
+	existing, err := gh.ListLabels(ctx, spec.Repo)
+	if err != nil {
+		return err
+	}
+	known := make([]string, 0, len(existing))
+	for _, label := range existing {
+		known = append(known, label.Name)
+	}
+	declared, err := r.declaredLabels(ctx, githubIssue.Namespace, spec.Repo)
+	if err != nil {
+		return err
+	}
+	known = append(known, declared...)
+
+	for _, name := range spec.Labels {
+		if containsFold(known, name) {
+			continue
+		}
+		log.FromContext(ctx).Info("creating missing GitHub label", "repo", spec.Repo, "label", name)
+		_, err := gh.CreateLabel(ctx, spec.Repo, github.Label{Name: name, Color: danaiov1alpha1.DefaultLabelColor})
+		if err != nil && !github.IsUnprocessable(err) {
+			return err
+		}
+		known = append(known, name)
+	}
+	return nil
+}
+
+// declaredLabels returns the names of the labels the GithubLabels in namespace
+// declare for repo.
+func (r *GithubIssueReconciler) declaredLabels(ctx context.Context, namespace, repo string) ([]string, error) {
+	labels := &danaiov1alpha1.GithubLabelList{}
+	if err := r.List(ctx, labels, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, label := range labels.Items {
+		if strings.EqualFold(label.Spec.Repo, repo) || strings.EqualFold(label.Status.Repo, repo) {
+			names = append(names, label.Spec.Name)
+		}
+	}
+	return names, nil
+}
+
+// containsFold reports whether values holds value, ignoring case as GitHub
+// does for label names.
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// milestoneCacheTTL is how long the milestone numbers of a repository are
+// trusted before they are listed again.
+const milestoneCacheTTL = resyncPeriod
+
+// milestoneError reports a milestone title the repository has no milestone
+// for. The issue waits for the milestone to be created instead of being
+// retried.
+type milestoneError struct {
+	message string
+}
+
+func (e *milestoneError) Error() string {
+	return e.message
+}
+
+// milestoneCache maps the milestone titles of repositories to their numbers.
+// A title missing from the cache lists the milestones of its repository
+// again, so that new milestones are found right away. It is safe for
+// concurrent use.
+type milestoneCache struct {
+	mu    sync.Mutex
+	repos map[milestoneKey]cachedMilestones
+}
+
+// milestoneKey identifies the milestones of a repository as seen by one set
+// of credentials, since the same owner/name can exist on several GitHub
+// instances and credentials can differ in the repositories they can read.
+type milestoneKey struct {
+	baseURL  string
+	identity string
+	repo     string
+}
+
+// newMilestoneKey returns the cache key of repo for creds. Tokens are hashed
+// so that the cache does not hold them.
+func newMilestoneKey(creds github.Credentials, repo string) milestoneKey {
+	identity := ""
+	switch {
+	case creds.IsApp():
+		identity = fmt.Sprintf("app/%d/%d", creds.AppID, creds.InstallationID)
+	case creds.Token != "":
+		sum := sha256.Sum256([]byte(creds.Token))
+		identity = "token/" + hex.EncodeToString(sum[:8])
+	}
+	return milestoneKey{baseURL: creds.BaseURL, identity: identity, repo: strings.ToLower(repo)}
+}
+
+// cachedMilestones are the milestone numbers of a repository by title.
+type cachedMilestones struct {
+	numbers map[string]int
+	listed  time.Time
+}
+
+// lookup returns the number of the open or closed milestone of repo with the given title.
+func (c *milestoneCache) lookup(ctx context.Context, gh github.IssueClient, creds github.Credentials,
+	repo, title string) (int, error) {
+	key := newMilestoneKey(creds, repo)
+	c.mu.Lock()
+	cached, ok := c.repos[key]
+	c.mu.Unlock()
+	if ok && time.Since(cached.listed) < milestoneCacheTTL {
+		if number, found := cached.numbers[title]; found {
+			return number, nil
+		}
+	}
+
+	milestones, err := gh.ListMilestones(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+	cached = cachedMilestones{numbers: make(map[string]int, len(milestones)), listed: time.Now()}
+	for _, milestone := range milestones {
+		cached.numbers[milestone.Title] = milestone.Number
+	}
+	c.mu.Lock()
+	if c.repos == nil {
+		c.repos = map[milestoneKey]cachedMilestones{}
+	}
+	c.repos[key] = cached
+	c.mu.Unlock()
+
+	number, found := cached.numbers[title]
+	if !found {
+		return 0, &milestoneError{fmt.Sprintf("milestone %q not found in %s", title, repo)}
+	}
+	return number, nil
+}
+
+// invalidate forgets the milestone numbers of repo listed with creds, so that
+// the next lookup lists them again.
+func (c *milestoneCache) invalidate(creds github.Credentials, repo string) {
+	c.mu.Lock()
+	delete(c.repos, newMilestoneKey(creds, repo))
+	c.mu.Unlock()
+}
+
+// resolveMilestone sets the milestone number of a resolved spec that refers
+// to its milestone by title.
+func (r *GithubIssueReconciler) resolveMilestone(ctx context.Context, gh github.IssueClient,
+	creds github.Credentials, spec danaiov1alpha1.GithubIssueSpec) (danaiov1alpha1.GithubIssueSpec, error) {
+	if spec.MilestoneTitle == "" {
+		return spec, nil
+	}
+	number, err := r.milestones.lookup(ctx, gh, creds, spec.Repo, spec.MilestoneTitle)
+	if err != nil {
+		return spec, err
+	}
+	spec.Milestone = &number
+	return spec, nil
+}
+
+// requestsForMilestone enqueues the GithubIssues in the namespace of the
+// GithubMilestone that refer to its title.
+func (r *GithubIssueReconciler) requestsForMilestone(ctx context.Context, obj client.Object) []reconcile.Request {
+	title := obj.(*danaiov1alpha1.GithubMilestone).Spec.Title
+	issues := &danaiov1alpha1.GithubIssueList{}
+	if err := r.List(ctx, issues, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubIssues")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, issue := range issues.Items {
+		if issue.Spec.MilestoneTitle == title {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&issue)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+)
+
+// crashLoopBackOff is the waiting reason of containers restarted with a backoff after crashing.
+const crashLoopBackOff = "CrashLoopBackOff"
+
+// maxLogTailBytes bounds the log quoted in a filed issue, keeping the body well below what GitHub accepts.
+const maxLogTailBytes = 32 << 10
+
+// maxTitleLength is the longest title a GithubIssue accepts.
+const maxTitleLength = 256
+
+// PodLogs reads the logs of containers.
+type PodLogs interface {
+	// TailLogs returns the last lines of the log of the previous, crashed
+	// instance of the container.
+	TailLogs(ctx context.Context, namespace, pod, container string, lines int64) (string, error)
+}
+
+// ClientsetPodLogs reads the logs of containers through the Kubernetes API.
+type ClientsetPodLogs struct {
+	kubernetes.Interface
+}
+
+// TailLogs implements PodLogs.
+func (l ClientsetPodLogs) TailLogs(ctx context.Context, namespace, pod, container string,
+	lines int64) (string, error) {
+	raw, err := l.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  true,
+		TailLines: &lines,
+	}).DoRaw(ctx)
+	return string(raw), err
+}
+
+// PodReconciler files GithubIssues for the containers of pods stuck in
+// CrashLoopBackOff, as configured by IssuePolicies.
+type PodReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Logs reads the log tails quoted in the filed issues. The issues leave
+	// the log out when it is nil.
+	Logs PodLogs
+
+	// Metadata reads the metadata of pods, such as the cache filled by the
+	// metadata-only watch of SetupWithManager. Reconcile uses the Client when
+	// it is nil.
+	Metadata client.Reader
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=issuepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissues,verbs=get;list;watch;create
+
+// Reconcile files a GithubIssue in the namespace of the pod for each of its
+// containers in CrashLoopBackOff that restarted at least as often as the
+// threshold of an IssuePolicy selecting the pod. Issues are named after the
+// policy, the workload running the pod and the container, so that the pods
+// of a workload crashing one after the other share a single issue. Pods are
+// reconciled whenever their status changes, which for crashing containers is
+// on every restart. Only pods selected by an IssuePolicy are read from the
+// API server; the others are matched on their cached metadata.
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	metadata := &metav1.PartialObjectMetadata{}
+	metadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+	if err := r.metadata().Get(ctx, req.NamespacedName, metadata); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	policies, err := r.policiesFor(ctx, metadata)
+	if err != nil || len(policies) == 0 {
+		return ctrl.Result{}, err
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	crashing := crashLoopingContainers(pod)
+
+	var errs []error
+	for i := range policies {
+		threshold := restartThreshold(&policies[i])
+		for _, status := range crashing {
+			if status.RestartCount < threshold {
+				continue
+			}
+			if err := r.fileIssue(ctx, &policies[i], pod, status); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// metadata returns the reader of pod metadata.
+func (r *PodReconciler) metadata() client.Reader {
+	if r.Metadata == nil {
+		return r.Client
+	}
+	return r.Metadata
+}
+
+// policiesFor returns the IssuePolicies selecting the pod. Policies with an
+// invalid selector are logged and skipped.
+func (r *PodReconciler) policiesFor(ctx context.Context, pod metav1.Object) ([]danaiov1alpha1.IssuePolicy, error) {
+	list := &danaiov1alpha1.IssuePolicyList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pod.GetNamespace()}, ns); err != nil {
+		return nil, err
+	}
+
+	var policies []danaiov1alpha1.IssuePolicy
+	for _, policy := range list.Items {
+		selected, err := policySelects(&policy, ns, pod)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "skipping IssuePolicy with an invalid selector", "issuePolicy", policy.Name)
+			continue
+		}
+		if selected {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+// policySelects reports whether the policy applies to the pod in namespace ns.
+func policySelects(policy *danaiov1alpha1.IssuePolicy, ns *corev1.Namespace, pod metav1.Object) (bool, error) {
+	if policy.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	if !selector.Matches(labels.Set(ns.Labels)) {
+		return false, nil
+	}
+	if policy.Spec.PodSelector == nil {
+		return true, nil
+	}
+	selector, err = metav1.LabelSelectorAsSelector(policy.Spec.PodSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid podSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(pod.GetLabels())), nil
+}
+
+// fileIssue creates the GithubIssue reporting the crash looping container,
+// unless one was filed before.
+func (r *PodReconciler) fileIssue(ctx context.Context, policy *danaiov1alpha1.IssuePolicy, pod *corev1.Pod,
+	status corev1.ContainerStatus) error {
+	workload := workloadName(pod)
+	key := types.NamespacedName{
+		Namespace: pod.Namespace,
+		Name:      crashLoopIssueName(policy.Name, workload, status.Name),
+	}
+	err := r.Get(ctx, key, &danaiov1alpha1.GithubIssue{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	githubIssue := &danaiov1alpha1.GithubIssue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Annotations: map[string]string{
+				danaiov1alpha1.IssuePolicyAnnotation: policy.Name,
+				danaiov1alpha1.PodAnnotation:         pod.Name,
+			},
+		},
+		Spec: danaiov1alpha1.GithubIssueSpec{
+			Repo:           policy.Spec.Repo,
+			RepositoryRef:  policy.Spec.RepositoryRef.DeepCopy(),
+			CredentialsRef: policy.Spec.CredentialsRef.DeepCopy(),
+			Title: truncateRunes(fmt.Sprintf("%s/%s: container %s is in CrashLoopBackOff",
+				pod.Namespace, workload, status.Name), maxTitleLength),
+			Description:    r.crashLoopBody(ctx, policy, pod, status),
+			Labels:         slices.Clone(policy.Spec.Labels),
+			Assignees:      slices.Clone(policy.Spec.Assignees),
+			SyncPolicy:     danaiov1alpha1.SyncPolicyCreateOnly,
+			DeletionPolicy: danaiov1alpha1.DeletionPolicyOrphan,
+		},
+	}
+	log.FromContext(ctx).Info("filing GithubIssue for crash looping container", "issuePolicy", policy.Name,
+		"githubIssue", key.Name, "container", status.Name, "restarts", status.RestartCount)
+	if err := r.Create(ctx, githubIssue); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// crashLoopBody describes the crash looping container in markdown, quoting
+// its last termination message and the tail of its log.
+func (r *PodReconciler) crashLoopBody(ctx context.Context, policy *danaiov1alpha1.IssuePolicy, pod *corev1.Pod,
+	status corev1.ContainerStatus) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Container `%s` of pod `%s/%s` is in CrashLoopBackOff after %d restarts.\n",
+		status.Name, pod.Namespace, pod.Name, status.RestartCount)
+
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		body.WriteString("\n### Last termination\n\n")
+		if terminated.Reason != "" {
+			fmt.Fprintf(&body, "- Reason: %s\n", terminated.Reason)
+		}
+		fmt.Fprintf(&body, "- Exit code: %d\n", terminated.ExitCode)
+		if terminated.Signal != 0 {
+			fmt.Fprintf(&body, "- Signal: %d\n", terminated.Signal)
+		}
+		if message := strings.TrimSpace(terminated.Message); message != "" {
+			body.WriteString("\n" + codeBlock(message))
+		}
+	}
+
+	lines := logTailLines(policy)
+	if lines == 0 || r.Logs == nil {
+		return body.String()
+	}
+	fmt.Fprintf(&body, "\n### Last %d log lines\n\n", lines)
+	logs, err := r.Logs.TailLogs(ctx, pod.Namespace, pod.Name, status.Name, lines)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to read the log of crash looping container", "container", status.Name)
+		fmt.Fprintf(&body, "The log could not be read: %v\n", err)
+		return body.String()
+	}
+	if len(logs) > maxLogTailBytes {
+		logs = strings.ToValidUTF8(logs[len(logs)-maxLogTailBytes:], "")
+	}
+	body.WriteString(codeBlock(strings.TrimRight(logs, "\n")))
+	return body.String()
+}
+
+// crashLoopingContainers returns the statuses of the containers of the pod,
+// init containers included, that are waiting in CrashLoopBackOff.
+func crashLoopingContainers(pod *corev1.Pod) []corev1.ContainerStatus {
+	var crashing []corev1.ContainerStatus
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOff {
+				crashing = append(crashing, status)
+			}
+		}
+	}
+	return crashing
+}
+
+// workloadName returns the name of the workload running the pod: its
+// controller, with the Deployment name recovered from ReplicaSets, or the pod
+// itself when it has none.
+func workloadName(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return pod.Name
+	}
+	if hash := pod.Labels["pod-template-hash"]; owner.Kind == "ReplicaSet" && hash != "" {
+		return strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return owner.Name
+}
+
+// crashLoopIssueName returns the name of the GithubIssue filed by the policy
+// for the container of the workload. Names too long for an object are cut
+// and made unique again with a hash.
+func crashLoopIssueName(policy, workload, container string) string {
+	name := fmt.Sprintf("%s-%s-%s", policy, workload, container)
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(sum[:4])
+	name = strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.")
+	return name + suffix
+}
+
+// restartThreshold returns the restart threshold of the policy.
+func restartThreshold(policy *danaiov1alpha1.IssuePolicy) int32 {
+	if policy.Spec.RestartThreshold <= 0 {
+		return danaiov1alpha1.DefaultRestartThreshold
+	}
+	return policy.Spec.RestartThreshold
+}
+
+// logTailLines returns how many log lines the issues of the policy quote.
+func logTailLines(policy *danaiov1alpha1.IssuePolicy) int64 {
+	if policy.Spec.LogTailLines == nil {
+		return danaiov1alpha1.DefaultLogTailLines
+	}
+	return *policy.Spec.LogTailLines
+}
+
+// codeBlock fences text as a markdown code block, with a fence longer than
+// any run of backticks in text.
+func codeBlock(text string) string {
+	longest, run := 0, 0
+	for _, c := range text {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + "\n" + text + "\n" + fence + "\n"
+}
+
+// truncateRunes cuts s to at most n runes.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// SetupWithManager sets up the controller with the Manager. Pods are watched by
+// metadata only, as whether a container is crash looping cannot be selected
+// on; Reconcile reads the pods selected by a policy from the API server, see
+// UncachedObjects.
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.OnlyMetadata, builder.WithPredicates(predicate.Funcs{
+			DeleteFunc: func(event.DeleteEvent) bool { return false },
+		})).
+		Named("pod").
+		Complete(r)
+}
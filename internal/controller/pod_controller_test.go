@@ -0,0 +1,273 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+)
+
+// fakePodLogs returns the same log for every container and records the reads.
+type fakePodLogs struct {
+	logs  string
+	err   error
+	reads []string
+}
+
+func (f *fakePodLogs) TailLogs(_ context.Context, namespace, pod, container string, lines int64) (string, error) {
+	f.reads = append(f.reads, fmt.Sprintf("%s/%s/%s:%d", namespace, pod, container, lines))
+	return f.logs, f.err
+}
+
+// podReadCounter counts the pods read through the client.
+type podReadCounter struct {
+	client.Client
+	reads int
+}
+
+func (c *podReadCounter) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Pod); ok {
+		c.reads++
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+var _ = Describe("Pod Controller", func() {
+	const policyName = "crashloop"
+	const repo = "TalDebi/GithubIssue"
+
+	ctx := context.Background()
+	var logs *fakePodLogs
+	var controllerReconciler *PodReconciler
+	var policy *danaiov1alpha1.IssuePolicy
+
+	BeforeEach(func() {
+		logs = &fakePodLogs{logs: "starting\npanic: missing config\n"}
+		controllerReconciler = &PodReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+			Logs:   logs,
+		}
+
+		policy = &danaiov1alpha1.IssuePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: policyName},
+			Spec: danaiov1alpha1.IssuePolicySpec{
+				Repo:              repo,
+				Labels:            []string{"crashloop"},
+				RestartThreshold:  3,
+				LogTailLines:      ptr.To[int64](20),
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, policy))).To(Succeed())
+
+		pods := &corev1.PodList{}
+		Expect(k8sClient.List(ctx, pods, client.InNamespace("default"))).To(Succeed())
+		for i := range pods.Items {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &pods.Items[i]))).To(Succeed())
+		}
+
+		githubIssues := &danaiov1alpha1.GithubIssueList{}
+		Expect(k8sClient.List(ctx, githubIssues, client.InNamespace("default"))).To(Succeed())
+		for i := range githubIssues.Items {
+			if githubIssues.Items[i].Annotations[danaiov1alpha1.IssuePolicyAnnotation] != "" {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &githubIssues.Items[i]))).To(Succeed())
+			}
+		}
+	})
+
+	// createPod creates a pod of the web Deployment whose app container is in
+	// CrashLoopBackOff after the given number of restarts.
+	createPod := func(name string, restarts int32) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web", "pod-template-hash": "5d8f7c9b4"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "web-5d8f7c9b4",
+					UID:        "6f1c9a5e-0000-4000-8000-000000000001",
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "example.com/web:1.0"}}},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:         "app",
+			Image:        "example.com/web:1.0",
+			RestartCount: restarts,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason:   "Error",
+					ExitCode: 2,
+					Message:  "config file /etc/web/config.yaml not found",
+				},
+			},
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+		return pod
+	}
+
+	reconcilePod := func(pod *corev1.Pod) {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(pod),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	issueKey := types.NamespacedName{Namespace: "default", Name: "crashloop-web-app"}
+
+	It("should file a GithubIssue once the restart threshold is reached", func() {
+		pod := createPod("web-5d8f7c9b4-x2k8p", 2)
+		reconcilePod(pod)
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, issueKey, &danaiov1alpha1.GithubIssue{}))).To(BeTrue())
+
+		By("restarting once more")
+		pod.Status.ContainerStatuses[0].RestartCount = 3
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+		reconcilePod(pod)
+
+		githubIssue := &danaiov1alpha1.GithubIssue{}
+		Expect(k8sClient.Get(ctx, issueKey, githubIssue)).To(Succeed())
+		Expect(githubIssue.Annotations).To(HaveKeyWithValue(danaiov1alpha1.IssuePolicyAnnotation, policyName))
+		Expect(githubIssue.Annotations).To(HaveKeyWithValue(danaiov1alpha1.PodAnnotation, pod.Name))
+		Expect(githubIssue.Spec.Repo).To(Equal(repo))
+		Expect(githubIssue.Spec.Title).To(Equal("default/web: container app is in CrashLoopBackOff"))
+		Expect(githubIssue.Spec.Labels).To(Equal([]string{"crashloop"}))
+		Expect(githubIssue.Spec.SyncPolicy).To(Equal(danaiov1alpha1.SyncPolicyCreateOnly))
+		Expect(githubIssue.Spec.DeletionPolicy).To(Equal(danaiov1alpha1.DeletionPolicyOrphan))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("after 3 restarts"))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("- Exit code: 2"))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("config file /etc/web/config.yaml not found"))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("```\nstarting\npanic: missing config\n```"))
+		Expect(logs.reads).To(Equal([]string{"default/web-5d8f7c9b4-x2k8p/app:20"}))
+	})
+
+	It("should file a single issue for the pods of a workload", func() {
+		reconcilePod(createPod("web-5d8f7c9b4-x2k8p", 3))
+		first := &danaiov1alpha1.GithubIssue{}
+		Expect(k8sClient.Get(ctx, issueKey, first)).To(Succeed())
+
+		reconcilePod(createPod("web-5d8f7c9b4-q9z7m", 4))
+
+		githubIssues := &danaiov1alpha1.GithubIssueList{}
+		Expect(k8sClient.List(ctx, githubIssues, client.InNamespace("default"))).To(Succeed())
+		filed := 0
+		for _, item := range githubIssues.Items {
+			if item.Annotations[danaiov1alpha1.IssuePolicyAnnotation] == policyName {
+				filed++
+			}
+		}
+		Expect(filed).To(Equal(1))
+		Expect(logs.reads).To(HaveLen(1))
+	})
+
+	It("should only file issues for the namespaces and pods the policy selects", func() {
+		pods := &podReadCounter{Client: k8sClient}
+		controllerReconciler.Client = pods
+		controllerReconciler.Metadata = k8sClient
+		policy.Spec.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"dana.io/crashloop-issues": "enabled"},
+		}
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		pod := createPod("web-5d8f7c9b4-x2k8p", 3)
+		reconcilePod(pod)
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, issueKey, &danaiov1alpha1.GithubIssue{}))).To(BeTrue())
+		Expect(pods.reads).To(BeZero())
+
+		By("selecting every namespace but other pods")
+		policy.Spec.NamespaceSelector = &metav1.LabelSelector{}
+		policy.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		reconcilePod(pod)
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, issueKey, &danaiov1alpha1.GithubIssue{}))).To(BeTrue())
+		Expect(pods.reads).To(BeZero())
+
+		By("selecting the pod")
+		policy.Spec.PodSelector.MatchLabels["app"] = "web"
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+		reconcilePod(pod)
+		Expect(k8sClient.Get(ctx, issueKey, &danaiov1alpha1.GithubIssue{})).To(Succeed())
+		Expect(pods.reads).To(Equal(1))
+	})
+
+	It("should not apply policies without a namespace selector", func() {
+		policy.Spec.NamespaceSelector = nil
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		reconcilePod(createPod("web-5d8f7c9b4-x2k8p", 3))
+
+		Expect(errors.IsNotFound(k8sClient.Get(ctx, issueKey, &danaiov1alpha1.GithubIssue{}))).To(BeTrue())
+	})
+
+	It("should file the issue without the log when it cannot be read", func() {
+		logs.err = fmt.Errorf("previous terminated container \"app\" not found")
+
+		reconcilePod(createPod("web-5d8f7c9b4-x2k8p", 3))
+
+		githubIssue := &danaiov1alpha1.GithubIssue{}
+		Expect(k8sClient.Get(ctx, issueKey, githubIssue)).To(Succeed())
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("The log could not be read"))
+	})
+
+	It("should leave the log out when the policy quotes no lines", func() {
+		policy.Spec.LogTailLines = ptr.To[int64](0)
+		Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+		reconcilePod(createPod("web-5d8f7c9b4-x2k8p", 3))
+
+		githubIssue := &danaiov1alpha1.GithubIssue{}
+		Expect(k8sClient.Get(ctx, issueKey, githubIssue)).To(Succeed())
+		Expect(githubIssue.Spec.Description).NotTo(ContainSubstring("log lines"))
+		Expect(logs.reads).To(BeEmpty())
+	})
+
+	It("should keep issue names valid for long workload names", func() {
+		name := crashLoopIssueName(policyName, strings.Repeat("w", 250), "app")
+		Expect(len(name)).To(BeNumerically("<=", 253))
+		Expect(name).NotTo(Equal(crashLoopIssueName(policyName, strings.Repeat("w", 250), "sidecar")))
+	})
+
+	It("should fence logs containing backticks", func() {
+		Expect(codeBlock("a ``` b")).To(Equal("````\na ``` b\n````\n"))
+	})
+})
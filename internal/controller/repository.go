@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+// repositoryRefIndex indexes GithubIssues by the name of the GithubRepository they reference.
+const repositoryRefIndex = ".spec.repositoryRef.name"
+
+// repositoryError reports a GithubRepository that cannot be found. The issue
+// waits for the GithubRepository to be created instead of being retried.
+type repositoryError struct {
+	message string
+}
+
+func (e *repositoryError) Error() string {
+	return e.message
+}
+
+// resolveSpec returns the spec of the GithubIssue with the defaults of its
+// GithubRepository merged in. Specs without a repositoryRef are returned as is.
+func (r *GithubIssueReconciler) resolveSpec(ctx context.Context,
+	githubIssue *danaiov1alpha1.GithubIssue) (danaiov1alpha1.GithubIssueSpec, error) {
+	spec := *githubIssue.Spec.DeepCopy()
+	if spec.RepositoryRef == nil {
+		return spec, nil
+	}
+
+	repository := &danaiov1alpha1.GithubRepository{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: githubIssue.Namespace, Name: spec.RepositoryRef.Name}, repository)
+	if apierrors.IsNotFound(err) {
+		return spec, &repositoryError{fmt.Sprintf("githubrepository %q not found", spec.RepositoryRef.Name)}
+	}
+	if err != nil {
+		return spec, err
+	}
+	return mergeRepositoryDefaults(spec, repository.Spec), nil
+}
+
+// mergeRepositoryDefaults applies the defaults of a GithubRepository to an
+// issue spec. Default labels are added to the issue's own, default assignees
+// and credentials only apply when the issue sets none, and the body footer is
+// appended to the description.
+func mergeRepositoryDefaults(spec danaiov1alpha1.GithubIssueSpec,
+	defaults danaiov1alpha1.GithubRepositorySpec) danaiov1alpha1.GithubIssueSpec {
+	spec.Repo = defaults.FullName()
+
+	for _, label := range defaults.DefaultLabels {
+		if !slices.Contains(spec.Labels, label) {
+			spec.Labels = append(spec.Labels, label)
+		}
+	}
+	if len(spec.Assignees) == 0 {
+		spec.Assignees = slices.Clone(defaults.DefaultAssignees)
+	}
+	spec.Description = joinDescription(spec.Description, defaults.BodyFooter)
+	if spec.TokenSecretRef == nil && spec.AppSecretRef == nil && spec.CredentialsRef == nil {
+		spec.TokenSecretRef = defaults.TokenSecretRef
+		spec.AppSecretRef = defaults.AppSecretRef
+		spec.CredentialsRef = defaults.CredentialsRef
+	}
+	return spec
+}
+
+// indexRepositoryRef extracts the GithubRepository name for repositoryRefIndex.
+func indexRepositoryRef(obj client.Object) []string {
+	spec := obj.(*danaiov1alpha1.GithubIssue).Spec
+	if spec.RepositoryRef == nil {
+		return nil
+	}
+	return []string{spec.RepositoryRef.Name}
+}
+
+// requestsForRepository enqueues the GithubIssues that reference the GithubRepository.
+func (r *GithubIssueReconciler) requestsForRepository(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsFor(ctx, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{repositoryRefIndex: obj.GetName()})
+}
+
+// requestsForRepositoriesMatching enqueues the GithubIssues of the
+// GithubRepositories in namespace, or in all namespaces when it is empty,
+// whose spec matches.
+func (r *GithubIssueReconciler) requestsForRepositoriesMatching(ctx context.Context, namespace string,
+	matches func(danaiov1alpha1.GithubRepositorySpec) bool) []reconcile.Request {
+	repositories := &danaiov1alpha1.GithubRepositoryList{}
+	if err := r.List(ctx, repositories, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list GithubRepositories")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, repository := range repositories.Items {
+		if matches(repository.Spec) {
+			requests = append(requests, r.requestsForRepository(ctx, &repository)...)
+		}
+	}
+	return requests
+}
+
+// repositoryTarget is the repository and credentials referenced by the spec
+// of an object managing part of a repository, such as a GithubLabel.
+type repositoryTarget struct {
+	repo           string
+	repositoryRef  *danaiov1alpha1.LocalRepositoryReference
+	tokenRef       *danaiov1alpha1.SecretKeyReference
+	appRef         *danaiov1alpha1.LocalSecretReference
+	credentialsRef *danaiov1alpha1.LocalCredentialsReference
+}
+
+// repositoryClient resolves target in namespace to a repository in
+// "owner/name" form and a GitHub client authenticated for it. The credentials
+// of a referenced GithubRepository are used when target references none.
+func (l *credentialsLoader) repositoryClient(ctx context.Context, factory github.ClientFactory, namespace string,
+	target repositoryTarget) (string, github.IssueClient, error) {
+	if target.repositoryRef != nil {
+		repository := &danaiov1alpha1.GithubRepository{}
+		err := l.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target.repositoryRef.Name}, repository)
+		if apierrors.IsNotFound(err) {
+			return "", nil, &repositoryError{fmt.Sprintf("githubrepository %q not found", target.repositoryRef.Name)}
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		target.repo = repository.Spec.FullName()
+		if target.tokenRef == nil && target.appRef == nil && target.credentialsRef == nil {
+			target.tokenRef, target.appRef = repository.Spec.TokenSecretRef, repository.Spec.AppSecretRef
+			target.credentialsRef = repository.Spec.CredentialsRef
+		}
+	}
+
+	creds, err := l.load(ctx, namespace, target.tokenRef, target.appRef, target.credentialsRef)
+	if err != nil {
+		return "", nil, err
+	}
+	gh, err := factory.IssueClient(ctx, creds)
+	return target.repo, gh, err
+}
+
+// conflictError reports a label or milestone that exists on GitHub but is not
+// owned by the object managing it. It is surfaced through the Conflict
+// condition rather than retried.
+type conflictError struct {
+	reason  string
+	message string
+}
+
+func (e *conflictError) Error() string {
+	return e.message
+}
+
+// deletionPolicyUnreachable reports whether err keeps the deletion policy
+// from ever being applied: the repository or the credentials it needs are
+// gone or refused, as when the namespace is being deleted. Retrying would
+// leave the object terminating for good.
+func deletionPolicyUnreachable(err error) bool {
+	var credsErr *credentialsError
+	var forbiddenErr *forbiddenError
+	var repoErr *repositoryError
+	return errors.As(err, &credsErr) || errors.As(err, &forbiddenErr) || errors.As(err, &repoErr) ||
+		errors.Is(err, github.ErrInvalidCredentials) || github.IsUnauthorized(err)
+}
+
+// syncFailure classifies an error syncing a GithubLabel or GithubMilestone
+// into the reason of its Ready condition and the result to return. Conflicts,
+// missing references and rejected credentials wait for the spec or the
+// referenced objects to change and are re-checked every resync period. Rate
+// limited syncs are requeued once the limit resets, and other errors are
+// retried with backoff.
+func syncFailure(err error, reason string) (string, ctrl.Result, error) {
+	var conflictErr *conflictError
+	var credsErr *credentialsError
+	var forbiddenErr *forbiddenError
+	var repoErr *repositoryError
+	retryAfter, rateLimited := github.RetryAfter(err)
+	switch {
+	case rateLimited:
+		return "RateLimited", ctrl.Result{RequeueAfter: retryAfter}, nil
+	case errors.As(err, &conflictErr):
+		return conflictErr.reason, ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.As(err, &forbiddenErr):
+		return "NamespaceNotAllowed", ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.As(err, &repoErr):
+		return "RepositoryNotFound", ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.As(err, &credsErr):
+		return "CredentialsNotFound", ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case errors.Is(err, github.ErrInvalidCredentials):
+		return "InvalidCredentials", ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	case github.IsUnauthorized(err):
+		return "Unauthorized", ctrl.Result{RequeueAfter: resyncPeriod}, nil
+	default:
+		return reason, ctrl.Result{}, err
+	}
+}
@@ -85,6 +85,8 @@ var _ = BeforeSuite(func() {
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
+	// The reconcilers list through the field indexes of the cache of the manager.
+	k8sClient = indexedClient{Client: k8sClient}
 
 })
 
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/issuetemplate"
+)
+
+// bodyTemplateConfigMapIndex indexes GithubIssues by the name of the ConfigMap holding their body template.
+const bodyTemplateConfigMapIndex = ".spec.bodyTemplate.configMapRef.name"
+
+// defaultTemplateKey is the key of the body template in a ConfigMap when the reference names none.
+const defaultTemplateKey = "template"
+
+// templateError reports a body template that cannot be rendered. The issue
+// waits for the spec or the ConfigMap to change instead of being retried.
+type templateError struct {
+	reason  string
+	message string
+}
+
+func (e *templateError) Error() string {
+	return e.message
+}
+
+// renderBody renders the body template of the resolved spec into its
+// description, keeping the footer of the GithubRepository merged in by
+// resolveSpec. Specs without a body template are returned as is.
+func (r *GithubIssueReconciler) renderBody(ctx context.Context, githubIssue *danaiov1alpha1.GithubIssue,
+	spec danaiov1alpha1.GithubIssueSpec) (danaiov1alpha1.GithubIssueSpec, error) {
+	if spec.BodyTemplate == nil {
+		return spec, nil
+	}
+
+	text, err := r.bodyTemplate(ctx, githubIssue.Namespace, spec.BodyTemplate)
+	if err != nil {
+		return spec, err
+	}
+	body, err := issuetemplate.Render(text, issuetemplate.Data{
+		Name:      githubIssue.Name,
+		Namespace: githubIssue.Namespace,
+		Title:     spec.Title,
+		Labels:    spec.Labels,
+		Values:    spec.TemplateData,
+	})
+	if err != nil {
+		return spec, &templateError{"RenderFailed", fmt.Sprintf("failed to render body template: %v", err)}
+	}
+
+	// Description and bodyTemplate are exclusive, so all the description
+	// holds at this point is the footer of the GithubRepository.
+	spec.Description = joinDescription(body, spec.Description)
+	return spec, nil
+}
+
+// bodyTemplate returns the text of the body template, reading it from its
+// ConfigMap when it is not inline.
+func (r *GithubIssueReconciler) bodyTemplate(ctx context.Context, namespace string,
+	tmpl *danaiov1alpha1.BodyTemplate) (string, error) {
+	ref := tmpl.ConfigMapRef
+	if ref == nil {
+		return tmpl.Inline, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, configMap)
+	if apierrors.IsNotFound(err) {
+		return "", &templateError{"ConfigMapNotFound", fmt.Sprintf("configmap %q not found", ref.Name)}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = defaultTemplateKey
+	}
+	text, ok := configMap.Data[key]
+	if !ok {
+		return "", &templateError{"TemplateKeyNotFound", fmt.Sprintf("configmap %q has no key %q", ref.Name, key)}
+	}
+	return text, nil
+}
+
+// joinDescription appends footer to description as a separate paragraph.
+func joinDescription(description, footer string) string {
+	switch {
+	case footer == "":
+		return description
+	case description == "":
+		return footer
+	default:
+		return description + "\n\n" + footer
+	}
+}
+
+// indexBodyTemplateConfigMap extracts the ConfigMap name for bodyTemplateConfigMapIndex.
+func indexBodyTemplateConfigMap(obj client.Object) []string {
+	spec := obj.(*danaiov1alpha1.GithubIssue).Spec
+	if spec.BodyTemplate == nil || spec.BodyTemplate.ConfigMapRef == nil {
+		return nil
+	}
+	return []string{spec.BodyTemplate.ConfigMapRef.Name}
+}
+
+// requestsForConfigMap enqueues the GithubIssues whose body template is held by the ConfigMap.
+func (r *GithubIssueReconciler) requestsForConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsFor(ctx, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{bodyTemplateConfigMapIndex: obj.GetName()})
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// appJWTLifetime is how long a minted app JWT is valid. GitHub allows at most ten minutes.
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the JWT issue time to tolerate clock drift.
+	appJWTClockSkew = time.Minute
+	// tokenRefreshMargin is how long before expiry a cached installation token is replaced.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// installationKey identifies an installation of a GitHub App.
+type installationKey struct {
+	baseURL        string
+	appID          int64
+	installationID int64
+}
+
+// installationToken is a cached installation access token.
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// AppTokenCache mints installation access tokens for GitHub Apps and caches
+// them until shortly before they expire. It is safe for concurrent use:
+// concurrent requests for the token of an installation share a single
+// exchange, and no lock is held while talking to GitHub, so installations do
+// not wait on each other.
+type AppTokenCache struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	tokens map[installationKey]installationToken
+
+	mints singleflight.Group
+}
+
+// NewAppTokenCache returns an empty cache.
+func NewAppTokenCache() *AppTokenCache {
+	return &AppTokenCache{
+		now:    time.Now,
+		tokens: map[installationKey]installationToken{},
+	}
+}
+
+// Token returns an installation access token for the app installation of
+// creds, minting one through api when there is no cached token or it is about
+// to expire. Tokens are cached per GitHub instance.
+func (c *AppTokenCache) Token(ctx context.Context, api *RESTClient, creds Credentials) (string, error) {
+	key := installationKey{baseURL: api.endpoints.API, appID: creds.AppID, installationID: creds.InstallationID}
+	if token, ok := c.cached(key); ok {
+		return token, nil
+	}
+
+	// The exchange is shared by the callers waiting on it, so it must not be
+	// cancelled with the context of the first one; the timeout of the HTTP
+	// client still bounds it.
+	mintCtx := context.WithoutCancel(ctx)
+	result := c.mints.DoChan(fmt.Sprintf("%s|%d|%d", key.baseURL, key.appID, key.installationID),
+		func() (any, error) {
+			if token, ok := c.cached(key); ok {
+				return token, nil
+			}
+			return c.mint(mintCtx, api, key, creds)
+		})
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	}
+}
+
+// cached returns the cached token of the installation unless it is about to expire.
+func (c *AppTokenCache) cached(key installationKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.tokens[key]
+	if !ok || !c.now().Add(tokenRefreshMargin).Before(cached.expiresAt) {
+		return "", false
+	}
+	return cached.token, true
+}
+
+// mint exchanges an app JWT for an installation access token and caches it.
+func (c *AppTokenCache) mint(ctx context.Context, api *RESTClient, key installationKey,
+	creds Credentials) (string, error) {
+	jwt, err := c.appJWT(creds.AppID, creds.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", creds.InstallationID)
+	appClient := &RESTClient{endpoints: api.endpoints, token: jwt, httpClient: api.httpClient}
+	if err := appClient.do(ctx, http.MethodPost, path, nil, &response); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens[key] = installationToken{token: response.Token, expiresAt: response.ExpiresAt}
+	return response.Token, nil
+}
+
+// appJWT mints the RS256 JWT that authenticates as the app itself.
+func (c *AppTokenCache) appJWT(appID int64, privateKey []byte) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	now := c.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey decodes a PEM encoded PKCS#1 or PKCS#8 RSA private key.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: private key is not PEM encoded", ErrInvalidCredentials)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private key is not an RSA key", ErrInvalidCredentials)
+	}
+	return key, nil
+}
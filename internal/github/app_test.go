@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("GitHub App authentication", func() {
+	const repo = "TalDebi/GithubIssue"
+
+	var (
+		ctx        context.Context
+		key        *rsa.PrivateKey
+		keyPEM     []byte
+		server     *httptest.Server
+		factory    *github.RESTClientFactory
+		mints      atomic.Int32
+		tokenTTL   time.Duration
+		authHeader atomic.Value
+		mintDelay  time.Duration
+		blocked    chan struct{}
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+		mints.Store(0)
+		tokenTTL = time.Hour
+		mintDelay = 0
+		blocked = make(chan struct{})
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /app/installations/42/access_tokens", func(w http.ResponseWriter, req *http.Request) {
+			claims := verifyJWT(&key.PublicKey, strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+			Expect(claims).To(HaveKeyWithValue("iss", "7"))
+			time.Sleep(mintDelay)
+			n := mints.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			Expect(json.NewEncoder(w).Encode(map[string]any{
+				"token":      fmt.Sprintf("installation-token-%d", n),
+				"expires_at": time.Now().Add(tokenTTL).UTC().Format(time.RFC3339),
+			})).To(Succeed())
+		})
+		// Installation 43 does not answer until blocked is closed.
+		mux.HandleFunc("POST /app/installations/43/access_tokens", func(w http.ResponseWriter, req *http.Request) {
+			<-blocked
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": "installation-token-43", "expires_at": %q}`,
+				time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		})
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues/1", func(w http.ResponseWriter, req *http.Request) {
+			authHeader.Store(req.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"number": 1}`)
+		})
+		server = httptest.NewServer(mux)
+		factory = github.NewRESTClientFactory(server.URL, server.Client())
+	})
+
+	AfterEach(func() {
+		select {
+		case <-blocked:
+		default:
+			close(blocked)
+		}
+		server.Close()
+	})
+
+	appCredentials := func() github.Credentials {
+		return github.Credentials{AppID: 7, InstallationID: 42, PrivateKey: keyPEM}
+	}
+
+	It("should exchange a signed JWT for an installation token", func() {
+		gh, err := factory.IssueClient(ctx, appCredentials())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = gh.Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(authHeader.Load()).To(Equal("Bearer installation-token-1"))
+	})
+
+	It("should reuse the cached token across clients", func() {
+		for i := 0; i < 3; i++ {
+			_, err := factory.IssueClient(ctx, appCredentials())
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(mints.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should mint a single token for concurrent clients of an installation", func() {
+		mintDelay = 50 * time.Millisecond
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := factory.IssueClient(ctx, appCredentials())
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+		Expect(mints.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should not hold up installations while another one is minting", func() {
+		stalled := appCredentials()
+		stalled.InstallationID = 43
+		done := make(chan error, 1)
+		go func() {
+			_, err := factory.IssueClient(ctx, stalled)
+			done <- err
+		}()
+		Consistently(done, 100*time.Millisecond).ShouldNot(Receive())
+
+		_, err := factory.IssueClient(ctx, appCredentials())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mints.Load()).To(BeEquivalentTo(1))
+
+		close(blocked)
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should stop waiting for a token once the context is done", func() {
+		stalled := appCredentials()
+		stalled.InstallationID = 43
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := factory.IssueClient(timeoutCtx, stalled)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should mint a new token shortly before the cached one expires", func() {
+		tokenTTL = 2 * time.Minute
+
+		_, err := factory.IssueClient(ctx, appCredentials())
+		Expect(err).NotTo(HaveOccurred())
+		gh, err := factory.IssueClient(ctx, appCredentials())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mints.Load()).To(BeEquivalentTo(2))
+		_, err = gh.Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(authHeader.Load()).To(Equal("Bearer installation-token-2"))
+	})
+
+	It("should reject a malformed private key", func() {
+		creds := appCredentials()
+		creds.PrivateKey = []byte("not a key")
+
+		_, err := factory.IssueClient(ctx, creds)
+		Expect(err).To(MatchError(github.ErrInvalidCredentials))
+		Expect(mints.Load()).To(BeZero())
+	})
+})
+
+// verifyJWT checks the RS256 signature of token and returns its claims.
+func verifyJWT(key *rsa.PublicKey, token string) map[string]any {
+	parts := strings.Split(token, ".")
+	Expect(parts).To(HaveLen(3))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	Expect(err).NotTo(HaveOccurred())
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	Expect(rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)).To(Succeed())
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	Expect(err).NotTo(HaveOccurred())
+	claims := map[string]any{}
+	Expect(json.Unmarshal(payload, &claims)).To(Succeed())
+	return claims
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultCacheSize is the default number of bytes of response bodies kept for
+// conditional requests, well within the memory limit of the manager.
+const DefaultCacheSize = 16 << 20
+
+// cacheRequests counts the conditional GET requests answered from the cache.
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "githubissue_github_cache_requests_total",
+	Help: "GitHub GET requests by cache result: hit for 304 Not Modified, miss otherwise.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(cacheRequests)
+}
+
+// cachedResponse is a response body with the validators to revalidate it.
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func (r *cachedResponse) size() int {
+	return len(r.key) + len(r.etag) + len(r.lastModified) + len(r.body)
+}
+
+// responseCache is an LRU cache of GET responses bounded by the total size of
+// its entries. It is safe for concurrent use.
+type responseCache struct {
+	maxBytes int
+
+	mu      sync.Mutex
+	bytes   int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// newResponseCache returns a cache holding at most maxBytes.
+func newResponseCache(maxBytes int) *responseCache {
+	return &responseCache{maxBytes: maxBytes, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// get returns the response stored under key and marks it as recently used.
+func (c *responseCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+// add stores a response, evicting the least recently used ones to stay within
+// maxBytes. Responses larger than the whole cache are not stored.
+func (c *responseCache) add(response *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[response.key]; ok {
+		c.remove(element)
+	}
+	if response.size() > c.maxBytes {
+		return
+	}
+
+	c.entries[response.key] = c.order.PushFront(response)
+	c.bytes += response.size()
+	for c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	response := c.order.Remove(element).(*cachedResponse)
+	delete(c.entries, response.key)
+	c.bytes -= response.size()
+}
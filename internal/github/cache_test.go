@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("Response cache", func() {
+	const repo = "TalDebi/GithubIssue"
+
+	var (
+		ctx         context.Context
+		server      *httptest.Server
+		mu          sync.Mutex
+		conditional []string
+		notModified int
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		conditional = nil
+		notModified = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			number := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			etag := `"issue-` + number + `"`
+			if match := req.Header.Get("If-None-Match"); match != "" {
+				conditional = append(conditional, number)
+				if match == etag {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			w.Header().Set("ETag", etag)
+			fmt.Fprintf(w, `{"number": %s, "title": "Issue %s"}`, number, number)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	issueClient := func(factory *github.RESTClientFactory) github.IssueClient {
+		gh, err := factory.IssueClient(ctx, github.Credentials{Token: "cache-token"})
+		Expect(err).NotTo(HaveOccurred())
+		return gh
+	}
+
+	cacheRequests := func(result string) float64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		for _, family := range families {
+			if family.GetName() != "githubissue_github_cache_requests_total" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				if metric.GetLabel()[0].GetValue() == result {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+
+	It("should revalidate cached responses with If-None-Match", func() {
+		factory := github.NewRESTClientFactory(server.URL, server.Client())
+		hits, misses := cacheRequests("hit"), cacheRequests("miss")
+
+		first, err := issueClient(factory).Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := issueClient(factory).Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second).To(Equal(first))
+		Expect(second.Title).To(Equal("Issue 1"))
+		Expect(notModified).To(Equal(1))
+		Expect(cacheRequests("hit")).To(Equal(hits + 1))
+		Expect(cacheRequests("miss")).To(Equal(misses + 1))
+	})
+
+	It("should evict the least recently used responses", func() {
+		// Room for about one response.
+		factory := github.NewRESTClientFactory(server.URL, server.Client()).WithCacheSize(200)
+		gh := issueClient(factory)
+
+		for _, number := range []int{1, 2, 1} {
+			_, err := gh.Get(ctx, repo, number)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(conditional).To(BeEmpty())
+
+		_, err := gh.Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conditional).To(Equal([]string{"1"}))
+	})
+
+	It("should not send conditional requests when the cache is disabled", func() {
+		factory := github.NewRESTClientFactory(server.URL, server.Client()).WithCacheSize(0)
+		gh := issueClient(factory)
+
+		for i := 0; i < 2; i++ {
+			_, err := gh.Get(ctx, repo, 1)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(conditional).To(BeEmpty())
+	})
+})
@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package github wraps the parts of the GitHub API used by the operator.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Issue states as reported by GitHub.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// Issue is the subset of the GitHub issue resource used by the operator.
+type Issue struct {
+	Number      int          `json:"number"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body"`
+	State       string       `json:"state"`
+	HTMLURL     string       `json:"html_url"`
+	NodeID      string       `json:"node_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Labels      []Label      `json:"labels,omitempty"`
+	Assignees   []User       `json:"assignees,omitempty"`
+	Milestone   *Milestone   `json:"milestone,omitempty"`
+	PullRequest *PullRequest `json:"pull_request,omitempty"`
+}
+
+// IsPullRequest reports whether the issue is actually a pull request. The
+// issues endpoints of GitHub return both.
+func (i *Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// LabelNames returns the names of the labels on the issue.
+func (i *Issue) LabelNames() []string {
+	names := make([]string, 0, len(i.Labels))
+	for _, label := range i.Labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// AssigneeLogins returns the logins of the users the issue is assigned to.
+func (i *Issue) AssigneeLogins() []string {
+	logins := make([]string, 0, len(i.Assignees))
+	for _, user := range i.Assignees {
+		logins = append(logins, user.Login)
+	}
+	return logins
+}
+
+// Label is a GitHub issue label. Color is a hex color code without the
+// leading "#".
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// User is a GitHub user.
+type User struct {
+	Login string `json:"login"`
+}
+
+// Milestone is a GitHub milestone.
+type Milestone struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state,omitempty"`
+	DueOn       *time.Time `json:"due_on,omitempty"`
+	HTMLURL     string     `json:"html_url,omitempty"`
+}
+
+// MilestoneRequest is the body of a milestone create or update call. All
+// fields are sent, so a nil DueOn clears the due date.
+type MilestoneRequest struct {
+	Title       string     `json:"title"`
+	State       string     `json:"state,omitempty"`
+	Description string     `json:"description"`
+	DueOn       *time.Time `json:"due_on"`
+}
+
+// PullRequest marks an issue that is a pull request.
+type PullRequest struct {
+	URL string `json:"url"`
+}
+
+// Comment is a comment on a GitHub issue.
+type Comment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+// IssueRequest is the body of an issue create or update call. Nil fields are
+// left out so that updates only touch what is set.
+type IssueRequest struct {
+	Title     *string   `json:"title,omitempty"`
+	Body      *string   `json:"body,omitempty"`
+	State     *string   `json:"state,omitempty"`
+	Labels    *[]string `json:"labels,omitempty"`
+	Assignees *[]string `json:"assignees,omitempty"`
+	Milestone *int      `json:"milestone,omitempty"`
+}
+
+// ListOptions filters the issues returned by List.
+type ListOptions struct {
+	// State is open, closed or all. Defaults to all.
+	State string
+	// CreatedSince, if set, only returns issues created at or after it,
+	// newest first.
+	CreatedSince time.Time
+}
+
+// IssueClient manages the issues of GitHub repositories. Repositories are
+// addressed in "owner/name" form.
+type IssueClient interface {
+	// Get returns a single issue.
+	Get(ctx context.Context, repo string, number int) (*Issue, error)
+	// List returns all issues of the repository, excluding pull requests.
+	List(ctx context.Context, repo string, opts ListOptions) ([]Issue, error)
+	// Create opens a new issue.
+	Create(ctx context.Context, repo string, req IssueRequest) (*Issue, error)
+	// Update edits the fields set in req.
+	Update(ctx context.Context, repo string, number int, req IssueRequest) (*Issue, error)
+	// Close closes the issue.
+	Close(ctx context.Context, repo string, number int) (*Issue, error)
+	// Lock locks the conversation of the issue.
+	Lock(ctx context.Context, repo string, number int) error
+	// Comment adds a comment to the issue.
+	Comment(ctx context.Context, repo string, number int, body string) (*Comment, error)
+	// ListComments returns all comments on the issue.
+	ListComments(ctx context.Context, repo string, number int) ([]Comment, error)
+	// GetComment returns a single comment.
+	GetComment(ctx context.Context, repo string, id int64) (*Comment, error)
+	// UpdateComment replaces the body of the comment.
+	UpdateComment(ctx context.Context, repo string, id int64, body string) (*Comment, error)
+	// DeleteComment deletes the comment.
+	DeleteComment(ctx context.Context, repo string, id int64) error
+	// AddLabels adds labels to the issue, keeping the ones already set.
+	AddLabels(ctx context.Context, repo string, number int, labels []string) error
+	// ListLabels returns all labels of the repository.
+	ListLabels(ctx context.Context, repo string) ([]Label, error)
+	// GetLabel returns the label with the given name, which GitHub matches case-insensitively.
+	GetLabel(ctx context.Context, repo, name string) (*Label, error)
+	// CreateLabel adds a label to the repository.
+	CreateLabel(ctx context.Context, repo string, label Label) (*Label, error)
+	// UpdateLabel replaces the name, color and description of the named label.
+	UpdateLabel(ctx context.Context, repo, name string, label Label) (*Label, error)
+	// DeleteLabel deletes the label from the repository and from all issues.
+	DeleteLabel(ctx context.Context, repo, name string) error
+	// ListMilestones returns all open and closed milestones of the repository.
+	ListMilestones(ctx context.Context, repo string) ([]Milestone, error)
+	// GetMilestone returns a single milestone.
+	GetMilestone(ctx context.Context, repo string, number int) (*Milestone, error)
+	// CreateMilestone adds a milestone to the repository.
+	CreateMilestone(ctx context.Context, repo string, req MilestoneRequest) (*Milestone, error)
+	// UpdateMilestone replaces the title, state, description and due date of the milestone.
+	UpdateMilestone(ctx context.Context, repo string, number int, req MilestoneRequest) (*Milestone, error)
+	// DeleteMilestone deletes the milestone, removing it from its issues.
+	DeleteMilestone(ctx context.Context, repo string, number int) error
+}
+
+// Credentials authenticate calls to GitHub, either with a token or as a
+// GitHub App installation.
+type Credentials struct {
+	// Token is a personal access token. Empty sends anonymous requests.
+	Token string
+
+	// AppID is the ID of the GitHub App. Setting it selects app authentication.
+	AppID int64
+	// InstallationID is the ID of the app installation to act as.
+	InstallationID int64
+	// PrivateKey is the PEM encoded private key of the app.
+	PrivateKey []byte
+
+	// BaseURL overrides the REST API base URL of the ClientFactory, such as
+	// https://ghe.example.com/api/v3 for GitHub Enterprise Server.
+	BaseURL string
+	// CABundle holds PEM encoded certificates trusted in addition to the
+	// system roots, for instances with self-signed certificates.
+	CABundle []byte
+}
+
+// IsApp reports whether the credentials authenticate as a GitHub App.
+func (c Credentials) IsApp() bool {
+	return c.AppID != 0
+}
+
+// ErrInvalidCredentials is returned for credentials that cannot be used at all,
+// such as a malformed private key.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ClientFactory returns IssueClients authenticated with the given credentials.
+type ClientFactory interface {
+	IssueClient(ctx context.Context, creds Credentials) (IssueClient, error)
+}
+
+// APIError is returned when GitHub answers with an error status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("github %s %s: %d %s: %s", e.Method, e.Path, e.StatusCode,
+		http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is a GitHub 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is a GitHub 401 response.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsUnprocessable reports whether err is a GitHub 422 response, which GitHub
+// also sends when creating something that already exists.
+func IsUnprocessable(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
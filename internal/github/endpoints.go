@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// enterpriseAPIPath is the path of the REST API on GitHub Enterprise Server.
+const enterpriseAPIPath = "/api/v3"
+
+// Endpoints are the URLs of the APIs served by one GitHub instance.
+type Endpoints struct {
+	// API is the base URL of the REST API.
+	API string
+	// Uploads is the base URL of the uploads API, used for release assets.
+	Uploads string
+	// GraphQL is the URL of the GraphQL API.
+	GraphQL string
+}
+
+// EndpointsFor derives the endpoints of a GitHub instance from the base URL of
+// its REST API. api.github.com maps to the public endpoints and a GitHub
+// Enterprise Server URL such as https://ghe.example.com/api/v3 to the
+// /api/uploads and /api/graphql endpoints of the same host. Any other URL is
+// assumed to serve all APIs below it, which is how proxies and test servers
+// are usually set up. An empty baseURL selects DefaultBaseURL.
+func EndpointsFor(baseURL string) Endpoints {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	api := strings.TrimSuffix(baseURL, "/")
+
+	switch {
+	case api == DefaultBaseURL:
+		return Endpoints{API: api, Uploads: "https://uploads.github.com", GraphQL: api + "/graphql"}
+	case strings.HasSuffix(api, enterpriseAPIPath):
+		root := strings.TrimSuffix(api, enterpriseAPIPath)
+		return Endpoints{API: api, Uploads: root + "/api/uploads", GraphQL: root + "/api/graphql"}
+	default:
+		return Endpoints{API: api, Uploads: api, GraphQL: api + "/graphql"}
+	}
+}
+
+// ValidateBaseURL checks that baseURL is an absolute http or https URL.
+func ValidateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return fmt.Errorf("%q is not an http or https URL", baseURL)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("%q has no host", baseURL)
+	}
+	return nil
+}
+
+const (
+	// RequestTimeout bounds a whole request to GitHub, reading the response included.
+	RequestTimeout = 30 * time.Second
+	// dialTimeout bounds establishing the TCP connection to GitHub.
+	dialTimeout = 10 * time.Second
+	// tlsHandshakeTimeout bounds the TLS handshake with GitHub.
+	tlsHandshakeTimeout = 10 * time.Second
+	// responseHeaderTimeout bounds waiting for GitHub to answer a sent request.
+	responseHeaderTimeout = 20 * time.Second
+)
+
+// defaultHTTPClient is used by clients given no HTTP client of their own.
+var defaultHTTPClient = newHTTPClient(nil)
+
+// NewHTTPClient returns an HTTP client with the timeouts requests to GitHub
+// need, so that a hung connection cannot block a reconcile forever. It trusts
+// the system roots plus the PEM encoded certificates in caBundle, for
+// instances with self-signed certificates.
+func NewHTTPClient(caBundle []byte) (*http.Client, error) {
+	if len(caBundle) == 0 {
+		return newHTTPClient(nil), nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("%w: CA bundle contains no PEM encoded certificates", ErrInvalidCredentials)
+	}
+	return newHTTPClient(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}), nil
+}
+
+// newHTTPClient returns an HTTP client with the GitHub timeouts, using
+// tlsConfig when it is set.
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = tlsHandshakeTimeout
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Transport: transport, Timeout: RequestTimeout}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("Endpoints", func() {
+	DescribeTable("should derive the uploads and GraphQL endpoints from the API base URL",
+		func(baseURL string, want github.Endpoints) {
+			Expect(github.EndpointsFor(baseURL)).To(Equal(want))
+		},
+		Entry("public GitHub", "https://api.github.com/", github.Endpoints{
+			API:     "https://api.github.com",
+			Uploads: "https://uploads.github.com",
+			GraphQL: "https://api.github.com/graphql",
+		}),
+		Entry("the default", "", github.Endpoints{
+			API:     "https://api.github.com",
+			Uploads: "https://uploads.github.com",
+			GraphQL: "https://api.github.com/graphql",
+		}),
+		Entry("GitHub Enterprise Server", "https://ghe.example.com/api/v3", github.Endpoints{
+			API:     "https://ghe.example.com/api/v3",
+			Uploads: "https://ghe.example.com/api/uploads",
+			GraphQL: "https://ghe.example.com/api/graphql",
+		}),
+		Entry("a proxy serving all APIs", "http://proxy.local:8080/github", github.Endpoints{
+			API:     "http://proxy.local:8080/github",
+			Uploads: "http://proxy.local:8080/github",
+			GraphQL: "http://proxy.local:8080/github/graphql",
+		}),
+	)
+
+	It("should only accept absolute http and https URLs", func() {
+		Expect(github.ValidateBaseURL("https://ghe.example.com/api/v3")).To(Succeed())
+		Expect(github.ValidateBaseURL("ghe.example.com/api/v3")).NotTo(Succeed())
+		Expect(github.ValidateBaseURL("ftp://ghe.example.com")).NotTo(Succeed())
+		Expect(github.ValidateBaseURL("https://")).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("RESTClientFactory", func() {
+	var (
+		ctx    context.Context
+		server *httptest.Server
+		caPEM  []byte
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Path).To(Equal("/api/v3/repos/TalDebi/GithubIssue/issues/1"))
+			fmt.Fprint(w, `{"number": 1}`)
+		}))
+		caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should talk to the base URL of the credentials, trusting their CA bundle", func() {
+		factory := github.NewRESTClientFactory(github.DefaultBaseURL, nil)
+
+		gh, err := factory.IssueClient(ctx, github.Credentials{BaseURL: server.URL + "/api/v3", CABundle: caPEM})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = gh.Get(ctx, "TalDebi/GithubIssue", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gh.(*github.RESTClient).Endpoints().GraphQL).To(Equal(server.URL + "/api/graphql"))
+	})
+
+	It("should not trust a self-signed certificate without a CA bundle", func() {
+		factory := github.NewRESTClientFactory(server.URL+"/api/v3", nil)
+
+		gh, err := factory.IssueClient(ctx, github.Credentials{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = gh.Get(ctx, "TalDebi/GithubIssue", 1)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should time out requests to a GitHub that does not answer", func() {
+		for _, caBundle := range [][]byte{nil, caPEM} {
+			httpClient, err := github.NewHTTPClient(caBundle)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(httpClient.Timeout).To(Equal(github.RequestTimeout))
+			Expect(httpClient.Transport.(*http.Transport).ResponseHeaderTimeout).To(BeNumerically(">", 0))
+		}
+	})
+
+	It("should reject a CA bundle without certificates", func() {
+		factory := github.NewRESTClientFactory(server.URL+"/api/v3", nil)
+
+		_, err := factory.IssueClient(ctx, github.Credentials{CABundle: []byte("not a certificate")})
+		Expect(err).To(MatchError(github.ErrInvalidCredentials))
+	})
+})
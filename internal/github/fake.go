@@ -0,0 +1,709 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeClient is an in-memory IssueClient for tests. It is safe for concurrent use.
+type FakeClient struct {
+	mu       sync.Mutex
+	issues   map[string][]*Issue
+	comments map[string][]Comment
+	// lastCommentID numbers comments across all issues, as GitHub does.
+	lastCommentID int64
+	locked        map[string]bool
+	labels        map[string][]Label
+	milestones    map[string][]*Milestone
+	calls         []string
+	writeErr      error
+	// creds are the credentials of the last IssueClient call, wantToken the token accepted.
+	creds     Credentials
+	wantToken string
+}
+
+var (
+	_ IssueClient   = &FakeClient{}
+	_ ClientFactory = &FakeClient{}
+)
+
+// NewFakeClient returns an empty FakeClient.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		issues:     map[string][]*Issue{},
+		comments:   map[string][]Comment{},
+		locked:     map[string]bool{},
+		labels:     map[string][]Label{},
+		milestones: map[string][]*Milestone{},
+	}
+}
+
+// AddIssue stores an open issue with the given title and body and returns a copy of it.
+func (f *FakeClient) AddIssue(repo, title, body string) *Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addIssue(repo, IssueRequest{Title: &title, Body: &body}).copy()
+}
+
+// Issue returns a copy of the stored issue, or nil if it does not exist.
+func (f *FakeClient) Issue(repo string, number int) *Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	issue := f.issue(repo, number)
+	if issue == nil {
+		return nil
+	}
+	return issue.copy()
+}
+
+// Comments returns the comments posted on an issue.
+func (f *FakeClient) Comments(repo string, number int) []Comment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.comments[issueKey(repo, number)])
+}
+
+// AddLabel stores a label in the repository as if it had been created outside the operator.
+func (f *FakeClient) AddLabel(repo string, label Label) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.labels[repo] = append(f.labels[repo], label)
+}
+
+// Labels returns the labels of the repository.
+func (f *FakeClient) Labels(repo string) []Label {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.labels[repo])
+}
+
+// AddMilestone stores an open milestone with the given title, as if it had
+// been created outside the operator, and returns a copy of it.
+func (f *FakeClient) AddMilestone(repo, title string) *Milestone {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addMilestone(repo, MilestoneRequest{Title: title}).copy()
+}
+
+// Milestone returns a copy of the stored milestone, or nil if it does not exist.
+func (f *FakeClient) Milestone(repo string, number int) *Milestone {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	milestone := f.milestone(repo, number)
+	if milestone == nil {
+		return nil
+	}
+	return milestone.copy()
+}
+
+// IsLocked reports whether the conversation of an issue was locked.
+func (f *FakeClient) IsLocked(repo string, number int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.locked[issueKey(repo, number)]
+}
+
+// SetWriteError makes every mutating call fail with err until it is reset with nil.
+func (f *FakeClient) SetWriteError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writeErr = err
+}
+
+// RequireToken makes every call fail with 401 unless the client was obtained
+// with the given token. An empty token accepts any credentials.
+func (f *FakeClient) RequireToken(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.wantToken = token
+}
+
+// Token returns the token of the credentials last passed to IssueClient.
+func (f *FakeClient) Token() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.creds.Token
+}
+
+// Credentials returns the credentials last passed to IssueClient.
+func (f *FakeClient) Credentials() Credentials {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.creds
+}
+
+// IssueClient records the credentials and returns the FakeClient itself.
+func (f *FakeClient) IssueClient(_ context.Context, creds Credentials) (IssueClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.creds = creds
+	return f, nil
+}
+
+// Calls returns the number of calls made to the named method, e.g. "Create".
+func (f *FakeClient) Calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, call := range f.calls {
+		if call == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Get returns a single issue.
+func (f *FakeClient) Get(_ context.Context, repo string, number int) (*Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "Get")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	issue := f.issue(repo, number)
+	if issue == nil {
+		return nil, notFound(http.MethodGet, issuePath(repo, number))
+	}
+	return issue.copy(), nil
+}
+
+// List returns all issues of the repository matching opts.
+func (f *FakeClient) List(_ context.Context, repo string, opts ListOptions) ([]Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "List")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	for _, issue := range f.issues[repo] {
+		if !opts.CreatedSince.IsZero() && issue.CreatedAt.Before(opts.CreatedSince) {
+			continue
+		}
+		if opts.State == "" || opts.State == "all" || opts.State == issue.State {
+			issues = append(issues, *issue.copy())
+		}
+	}
+	if !opts.CreatedSince.IsZero() {
+		slices.Reverse(issues)
+	}
+	return issues, nil
+}
+
+// Create opens a new issue. Milestones the repository does not have fail with 422.
+func (f *FakeClient) Create(_ context.Context, repo string, req IssueRequest) (*Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "Create")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	if req.Milestone != nil && f.milestone(repo, *req.Milestone) == nil {
+		return nil, &APIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPost,
+			Path: fmt.Sprintf("/repos/%s/issues", repo), Message: "Validation Failed"}
+	}
+	return f.addIssue(repo, req).copy(), nil
+}
+
+// Update edits the fields set in req. Milestones the repository does not
+// have fail with 422.
+func (f *FakeClient) Update(_ context.Context, repo string, number int, req IssueRequest) (*Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "Update")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	issue := f.issue(repo, number)
+	if issue == nil {
+		return nil, notFound(http.MethodPatch, issuePath(repo, number))
+	}
+	if req.Milestone != nil && f.milestone(repo, *req.Milestone) == nil {
+		return nil, &APIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPatch,
+			Path: issuePath(repo, number), Message: "Validation Failed"}
+	}
+	issue.apply(req)
+	return issue.copy(), nil
+}
+
+// Close closes the issue.
+func (f *FakeClient) Close(ctx context.Context, repo string, number int) (*Issue, error) {
+	state := StateClosed
+	return f.Update(ctx, repo, number, IssueRequest{State: &state})
+}
+
+// Lock locks the conversation of the issue.
+func (f *FakeClient) Lock(_ context.Context, repo string, number int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "Lock")
+	if err := f.authorize(); err != nil {
+		return err
+	}
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	if f.issue(repo, number) == nil {
+		return notFound(http.MethodPut, issuePath(repo, number)+"/lock")
+	}
+	f.locked[issueKey(repo, number)] = true
+	return nil
+}
+
+// Comment adds a comment to the issue.
+func (f *FakeClient) Comment(_ context.Context, repo string, number int, body string) (*Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "Comment")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	if f.issue(repo, number) == nil {
+		return nil, notFound(http.MethodPost, issuePath(repo, number)+"/comments")
+	}
+	key := issueKey(repo, number)
+	f.lastCommentID++
+	comment := Comment{
+		ID:      f.lastCommentID,
+		Body:    body,
+		HTMLURL: fmt.Sprintf("https://github.com/%s/issues/%d#issuecomment-%d", repo, number, f.lastCommentID),
+	}
+	f.comments[key] = append(f.comments[key], comment)
+	return &comment, nil
+}
+
+// ListComments returns all comments on the issue.
+func (f *FakeClient) ListComments(_ context.Context, repo string, number int) ([]Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "ListComments")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.issue(repo, number) == nil {
+		return nil, notFound(http.MethodGet, issuePath(repo, number)+"/comments")
+	}
+	return slices.Clone(f.comments[issueKey(repo, number)]), nil
+}
+
+// GetComment returns a single comment.
+func (f *FakeClient) GetComment(_ context.Context, repo string, id int64) (*Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "GetComment")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	key, i := f.comment(repo, id)
+	if i < 0 {
+		return nil, notFound(http.MethodGet, commentPath(repo, id))
+	}
+	comment := f.comments[key][i]
+	return &comment, nil
+}
+
+// UpdateComment replaces the body of the comment.
+func (f *FakeClient) UpdateComment(_ context.Context, repo string, id int64, body string) (*Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "UpdateComment")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	key, i := f.comment(repo, id)
+	if i < 0 {
+		return nil, notFound(http.MethodPatch, commentPath(repo, id))
+	}
+	f.comments[key][i].Body = body
+	comment := f.comments[key][i]
+	return &comment, nil
+}
+
+// DeleteComment deletes the comment.
+func (f *FakeClient) DeleteComment(_ context.Context, repo string, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "DeleteComment")
+	if err := f.authorize(); err != nil {
+		return err
+	}
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	key, i := f.comment(repo, id)
+	if i < 0 {
+		return notFound(http.MethodDelete, commentPath(repo, id))
+	}
+	f.comments[key] = slices.Delete(f.comments[key], i, i+1)
+	return nil
+}
+
+// AddLabels adds labels to the issue, keeping the ones already set.
+func (f *FakeClient) AddLabels(_ context.Context, repo string, number int, labels []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "AddLabels")
+	if err := f.authorize(); err != nil {
+		return err
+	}
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	issue := f.issue(repo, number)
+	if issue == nil {
+		return notFound(http.MethodPost, issuePath(repo, number)+"/labels")
+	}
+	for _, name := range labels {
+		if !slices.Contains(issue.LabelNames(), name) {
+			issue.Labels = append(issue.Labels, Label{Name: name})
+		}
+	}
+	return nil
+}
+
+// ListLabels returns all labels of the repository.
+func (f *FakeClient) ListLabels(_ context.Context, repo string) ([]Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "ListLabels")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.labels[repo]), nil
+}
+
+// GetLabel returns the label with the given name, matched case-insensitively.
+func (f *FakeClient) GetLabel(_ context.Context, repo, name string) (*Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "GetLabel")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	i := f.label(repo, name)
+	if i < 0 {
+		return nil, notFound(http.MethodGet, labelPath(repo, name))
+	}
+	label := f.labels[repo][i]
+	return &label, nil
+}
+
+// CreateLabel adds a label to the repository. Names already taken fail with 422.
+func (f *FakeClient) CreateLabel(_ context.Context, repo string, label Label) (*Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "CreateLabel")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	if f.label(repo, label.Name) >= 0 {
+		return nil, &APIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPost,
+			Path: fmt.Sprintf("/repos/%s/labels", repo), Message: "Validation Failed"}
+	}
+	f.labels[repo] = append(f.labels[repo], label)
+	return &label, nil
+}
+
+// UpdateLabel replaces the name, color and description of the named label.
+func (f *FakeClient) UpdateLabel(_ context.Context, repo, name string, label Label) (*Label, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "UpdateLabel")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	i := f.label(repo, name)
+	if i < 0 {
+		return nil, notFound(http.MethodPatch, labelPath(repo, name))
+	}
+	f.labels[repo][i] = label
+	return &label, nil
+}
+
+// DeleteLabel deletes the label from the repository and from all issues.
+func (f *FakeClient) DeleteLabel(_ context.Context, repo, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "DeleteLabel")
+	if err := f.authorize(); err != nil {
+		return err
+	}
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	i := f.label(repo, name)
+	if i < 0 {
+		return notFound(http.MethodDelete, labelPath(repo, name))
+	}
+	f.labels[repo] = slices.Delete(f.labels[repo], i, i+1)
+	for _, issue := range f.issues[repo] {
+		issue.Labels = slices.DeleteFunc(issue.Labels, func(l Label) bool { return strings.EqualFold(l.Name, name) })
+	}
+	return nil
+}
+
+// ListMilestones returns all open and closed milestones of the repository.
+func (f *FakeClient) ListMilestones(_ context.Context, repo string) ([]Milestone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "ListMilestones")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	var milestones []Milestone
+	for _, milestone := range f.milestones[repo] {
+		if milestone != nil {
+			milestones = append(milestones, *milestone.copy())
+		}
+	}
+	return milestones, nil
+}
+
+// GetMilestone returns a single milestone.
+func (f *FakeClient) GetMilestone(_ context.Context, repo string, number int) (*Milestone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "GetMilestone")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	milestone := f.milestone(repo, number)
+	if milestone == nil {
+		return nil, notFound(http.MethodGet, milestonePath(repo, number))
+	}
+	return milestone.copy(), nil
+}
+
+// CreateMilestone adds a milestone to the repository. Titles already taken fail with 422.
+func (f *FakeClient) CreateMilestone(_ context.Context, repo string, req MilestoneRequest) (*Milestone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "CreateMilestone")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	for _, milestone := range f.milestones[repo] {
+		if milestone != nil && milestone.Title == req.Title {
+			return nil, &APIError{StatusCode: http.StatusUnprocessableEntity, Method: http.MethodPost,
+				Path: fmt.Sprintf("/repos/%s/milestones", repo), Message: "Validation Failed"}
+		}
+	}
+	return f.addMilestone(repo, req).copy(), nil
+}
+
+// UpdateMilestone replaces the title, state, description and due date of the milestone.
+func (f *FakeClient) UpdateMilestone(_ context.Context, repo string, number int,
+	req MilestoneRequest) (*Milestone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "UpdateMilestone")
+	if err := f.authorize(); err != nil {
+		return nil, err
+	}
+	if f.writeErr != nil {
+		return nil, f.writeErr
+	}
+	milestone := f.milestone(repo, number)
+	if milestone == nil {
+		return nil, notFound(http.MethodPatch, milestonePath(repo, number))
+	}
+	milestone.apply(req)
+	return milestone.copy(), nil
+}
+
+// DeleteMilestone deletes the milestone, removing it from its issues.
+func (f *FakeClient) DeleteMilestone(_ context.Context, repo string, number int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "DeleteMilestone")
+	if err := f.authorize(); err != nil {
+		return err
+	}
+	if f.writeErr != nil {
+		return f.writeErr
+	}
+	if f.milestone(repo, number) == nil {
+		return notFound(http.MethodDelete, milestonePath(repo, number))
+	}
+	f.milestones[repo][number-1] = nil
+	for _, issue := range f.issues[repo] {
+		if issue.Milestone != nil && issue.Milestone.Number == number {
+			issue.Milestone = nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeClient) authorize() error {
+	if f.wantToken != "" && f.creds.Token != f.wantToken {
+		return &APIError{StatusCode: http.StatusUnauthorized, Message: "Bad credentials"}
+	}
+	return nil
+}
+
+func (f *FakeClient) issue(repo string, number int) *Issue {
+	if number < 1 || number > len(f.issues[repo]) {
+		return nil
+	}
+	return f.issues[repo][number-1]
+}
+
+// comment returns the issue key and index of the comment, or -1 if the repository has no such comment.
+func (f *FakeClient) comment(repo string, id int64) (string, int) {
+	for number := range f.issues[repo] {
+		key := issueKey(repo, number+1)
+		if i := slices.IndexFunc(f.comments[key], func(c Comment) bool { return c.ID == id }); i >= 0 {
+			return key, i
+		}
+	}
+	return "", -1
+}
+
+// label returns the index of the named label in the repository, or -1 if it does not exist.
+func (f *FakeClient) label(repo, name string) int {
+	return slices.IndexFunc(f.labels[repo], func(l Label) bool { return strings.EqualFold(l.Name, name) })
+}
+
+// milestone returns the stored milestone, or nil if it does not exist or was deleted.
+func (f *FakeClient) milestone(repo string, number int) *Milestone {
+	if number < 1 || number > len(f.milestones[repo]) {
+		return nil
+	}
+	return f.milestones[repo][number-1]
+}
+
+func (f *FakeClient) addMilestone(repo string, req MilestoneRequest) *Milestone {
+	number := len(f.milestones[repo]) + 1
+	milestone := &Milestone{
+		Number:  number,
+		State:   StateOpen,
+		HTMLURL: fmt.Sprintf("https://github.com/%s/milestone/%d", repo, number),
+	}
+	milestone.apply(req)
+	f.milestones[repo] = append(f.milestones[repo], milestone)
+	return milestone
+}
+
+func (m *Milestone) apply(req MilestoneRequest) {
+	m.Title = req.Title
+	m.Description = req.Description
+	if req.State != "" {
+		m.State = req.State
+	}
+	m.DueOn = nil
+	if req.DueOn != nil {
+		dueOn := *req.DueOn
+		m.DueOn = &dueOn
+	}
+}
+
+func (m *Milestone) copy() *Milestone {
+	copied := *m
+	if m.DueOn != nil {
+		dueOn := *m.DueOn
+		copied.DueOn = &dueOn
+	}
+	return &copied
+}
+
+func (f *FakeClient) addIssue(repo string, req IssueRequest) *Issue {
+	number := len(f.issues[repo]) + 1
+	issue := &Issue{
+		Number:    number,
+		State:     StateOpen,
+		HTMLURL:   fmt.Sprintf("https://github.com/%s/issues/%d", repo, number),
+		NodeID:    fmt.Sprintf("I_fake_%d", number),
+		CreatedAt: time.Now(),
+	}
+	issue.apply(req)
+	f.issues[repo] = append(f.issues[repo], issue)
+	return issue
+}
+
+func (i *Issue) apply(req IssueRequest) {
+	if req.Title != nil {
+		i.Title = *req.Title
+	}
+	if req.Body != nil {
+		i.Body = *req.Body
+	}
+	if req.State != nil {
+		i.State = *req.State
+	}
+	if req.Labels != nil {
+		i.Labels = nil
+		for _, name := range *req.Labels {
+			i.Labels = append(i.Labels, Label{Name: name})
+		}
+	}
+	if req.Assignees != nil {
+		i.Assignees = nil
+		for _, login := range *req.Assignees {
+			i.Assignees = append(i.Assignees, User{Login: login})
+		}
+	}
+	if req.Milestone != nil {
+		i.Milestone = &Milestone{Number: *req.Milestone}
+	}
+}
+
+func (i *Issue) copy() *Issue {
+	copied := *i
+	copied.Labels = slices.Clone(i.Labels)
+	copied.Assignees = slices.Clone(i.Assignees)
+	if i.Milestone != nil {
+		copied.Milestone = i.Milestone.copy()
+	}
+	return &copied
+}
+
+func issueKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+func notFound(method, path string) error {
+	return &APIError{StatusCode: http.StatusNotFound, Method: method, Path: path, Message: "Not Found"}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// markerPrefix starts every ownership marker, followed by the format version.
+const markerPrefix = "githubissue-operator:"
+
+// markerVersion is the version of the marker format written by WithMarker.
+const markerVersion = "v1"
+
+// markerPattern matches a marker of any version, capturing version and fields.
+var markerPattern = regexp.MustCompile(`\n*<!-- ` + regexp.QuoteMeta(markerPrefix) + `(v\d+)((?: [a-z]+=\S*)*) -->`)
+
+// Marker identifies the object that owns an issue. It is embedded in the
+// issue body as a hidden HTML comment so the owner can find the issue again
+// when it was created but never recorded.
+type Marker struct {
+	ClusterID string
+	Namespace string
+	Name      string
+	UID       string
+}
+
+// String formats the marker as an HTML comment, escaping the field values.
+func (m Marker) String() string {
+	fields := []string{
+		"cluster=" + url.QueryEscape(m.ClusterID),
+		"namespace=" + url.QueryEscape(m.Namespace),
+		"name=" + url.QueryEscape(m.Name),
+		"uid=" + url.QueryEscape(m.UID),
+	}
+	return "<!-- " + markerPrefix + markerVersion + " " + strings.Join(fields, " ") + " -->"
+}
+
+// Owns reports whether other identifies the same object as m.
+func (m Marker) Owns(other Marker) bool {
+	return m == other
+}
+
+// ParseMarker returns the first marker in body. Markers of unknown versions
+// are ignored.
+func ParseMarker(body string) (Marker, bool) {
+	for _, match := range markerPattern.FindAllStringSubmatch(body, -1) {
+		if match[1] != markerVersion {
+			continue
+		}
+		var m Marker
+		for _, field := range strings.Fields(match[2]) {
+			key, value, _ := strings.Cut(field, "=")
+			value, err := url.QueryUnescape(value)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "cluster":
+				m.ClusterID = value
+			case "namespace":
+				m.Namespace = value
+			case "name":
+				m.Name = value
+			case "uid":
+				m.UID = value
+			}
+		}
+		return m, true
+	}
+	return Marker{}, false
+}
+
+// StripMarker removes all markers from body.
+func StripMarker(body string) string {
+	return markerPattern.ReplaceAllString(body, "")
+}
+
+// WithMarker returns body with its markers replaced by m, appended at the end.
+func WithMarker(body string, m Marker) string {
+	body = StripMarker(body)
+	if body == "" {
+		return m.String()
+	}
+	return body + "\n\n" + m.String()
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("Marker", func() {
+	marker := github.Marker{ClusterID: "prod east", Namespace: "team-a", Name: "flaky-test", UID: "1234-abcd"}
+
+	It("should round-trip through an issue body", func() {
+		body := github.WithMarker("Some description", marker)
+
+		Expect(body).To(HavePrefix("Some description\n\n<!-- githubissue-operator:v1 "))
+		Expect(body).To(ContainSubstring("cluster=prod+east"))
+		parsed, ok := github.ParseMarker(body)
+		Expect(ok).To(BeTrue())
+		Expect(parsed).To(Equal(marker))
+		Expect(github.StripMarker(body)).To(Equal("Some description"))
+	})
+
+	It("should replace an existing marker", func() {
+		other := github.Marker{ClusterID: "dev", Namespace: "team-b", Name: "old", UID: "5678"}
+
+		body := github.WithMarker(github.WithMarker("Body", other), marker)
+
+		Expect(github.StripMarker(body)).To(Equal("Body"))
+		parsed, ok := github.ParseMarker(body)
+		Expect(ok).To(BeTrue())
+		Expect(parsed.Owns(marker)).To(BeTrue())
+	})
+
+	It("should write only the marker for an empty body", func() {
+		Expect(github.WithMarker("", marker)).To(Equal(marker.String()))
+	})
+
+	It("should ignore bodies without a marker of a known version", func() {
+		_, ok := github.ParseMarker("Plain body <!-- a comment -->")
+		Expect(ok).To(BeFalse())
+		_, ok = github.ParseMarker("<!-- githubissue-operator:v9 uid=1234 -->")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultRequestsPerHour is the primary rate limit of GitHub for personal
+	// access tokens and app installations.
+	DefaultRequestsPerHour = 5000
+	// DefaultBurst is how many requests a credential may send at once.
+	DefaultBurst = 100
+	// maxLimiterWait is how long a request waits for the token bucket before
+	// it fails with a RateLimitError instead.
+	maxLimiterWait = 2 * time.Second
+	// minRetryAfter is the shortest wait reported for a rate limited request.
+	minRetryAfter = time.Second
+)
+
+// rateLimitRemaining exposes the quota GitHub reported for each credential.
+var rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "githubissue_github_rate_limit_remaining",
+	Help: "Requests left in the current GitHub rate limit window, per credential.",
+}, []string{"credential"})
+
+func init() {
+	metrics.Registry.MustRegister(rateLimitRemaining)
+}
+
+// RateLimitError is returned when GitHub refused a request because of its
+// primary or secondary rate limits, or when the request would exceed the
+// local budget of the credential.
+type RateLimitError struct {
+	// RetryAfter is how long to wait before sending the request again.
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("github rate limit exceeded, retry after %s: %s", e.RetryAfter, e.Message)
+}
+
+// RetryAfter returns how long to wait before retrying when err is a RateLimitError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return 0, false
+	}
+	return rateLimitErr.RetryAfter, true
+}
+
+// rateLimit is the request budget of one credential: a local token bucket plus
+// the quota last reported by GitHub. It is safe for concurrent use.
+type rateLimit struct {
+	// credential labels the metrics of the credential without revealing it.
+	credential string
+	limiter    *rate.Limiter
+	now        func() time.Time
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// newRateLimit returns a budget of requestsPerHour with the given burst. A
+// non-positive requestsPerHour only enforces the limits reported by GitHub.
+func newRateLimit(credential string, requestsPerHour, burst int) *rateLimit {
+	limit := rate.Inf
+	if requestsPerHour > 0 {
+		limit = rate.Limit(float64(requestsPerHour) / time.Hour.Seconds())
+	}
+	return &rateLimit{credential: credential, limiter: rate.NewLimiter(limit, burst), now: time.Now}
+}
+
+// wait takes a token for one request. It fails with a RateLimitError while
+// GitHub reports the quota as exhausted, and when the bucket takes longer
+// than maxLimiterWait to refill. A nil rateLimit never waits.
+func (l *rateLimit) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	blocked := l.blockedUntil.Sub(l.now())
+	l.mu.Unlock()
+	if blocked > 0 {
+		return &RateLimitError{RetryAfter: blocked, Message: "quota exhausted"}
+	}
+
+	reservation := l.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay > maxLimiterWait {
+		reservation.Cancel()
+		return &RateLimitError{RetryAfter: delay, Message: "local request budget exhausted"}
+	}
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe records the quota reported in a response and returns a
+// RateLimitError when the response refused the request because of it.
+func (l *rateLimit) observe(resp *http.Response) error {
+	now := time.Now
+	if l != nil {
+		now = l.now
+	}
+
+	remaining, reset, hasQuota := quota(resp)
+	var blockedUntil time.Time
+	switch {
+	case resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests:
+	case resp.Header.Get("Retry-After") != "":
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil {
+			blockedUntil = now().Add(time.Duration(seconds) * time.Second)
+		}
+	case hasQuota && remaining == 0:
+		blockedUntil = reset
+	}
+
+	if l != nil {
+		if hasQuota {
+			rateLimitRemaining.WithLabelValues(l.credential).Set(float64(remaining))
+		}
+		if !blockedUntil.IsZero() {
+			l.mu.Lock()
+			if blockedUntil.After(l.blockedUntil) {
+				l.blockedUntil = blockedUntil
+			}
+			l.mu.Unlock()
+		}
+	}
+
+	if blockedUntil.IsZero() {
+		return nil
+	}
+	return &RateLimitError{RetryAfter: max(blockedUntil.Sub(now()), minRetryAfter), Message: resp.Status}
+}
+
+// quota parses the X-RateLimit-Remaining and X-RateLimit-Reset headers.
+func quota(resp *http.Response) (remaining int, reset time.Time, ok bool) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	resetUnix, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return remaining, time.Time{}, true
+	}
+	return remaining, time.Unix(resetUnix, 0), true
+}
+
+// credentialLabel identifies creds in metrics without revealing secrets.
+func credentialLabel(creds Credentials) string {
+	switch {
+	case creds.IsApp():
+		return fmt.Sprintf("app/%d/%d", creds.AppID, creds.InstallationID)
+	case creds.Token != "":
+		sum := sha256.Sum256([]byte(creds.Token))
+		return "token/" + hex.EncodeToString(sum[:6])
+	default:
+		return "anonymous"
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("Rate limits", func() {
+	const repo = "TalDebi/GithubIssue"
+
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		requests atomic.Int32
+		respond  func(w http.ResponseWriter)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests.Store(0)
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			fmt.Fprint(w, `{"number": 1}`)
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			respond(w)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	issueClient := func(factory *github.RESTClientFactory, token string) github.IssueClient {
+		gh, err := factory.IssueClient(ctx, github.Credentials{Token: token})
+		Expect(err).NotTo(HaveOccurred())
+		return gh
+	}
+
+	It("should stop calling GitHub until the primary rate limit resets", func() {
+		reset := time.Now().Add(30 * time.Minute)
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "API rate limit exceeded"}`)
+		}
+		factory := github.NewRESTClientFactory(server.URL, server.Client())
+
+		_, err := issueClient(factory, "exhausted-token").Get(ctx, repo, 1)
+		retryAfter, ok := github.RetryAfter(err)
+		Expect(ok).To(BeTrue())
+		Expect(retryAfter).To(BeNumerically("~", 30*time.Minute, 5*time.Second))
+
+		By("sharing the exhausted quota with new clients of the same credential")
+		_, err = issueClient(factory, "exhausted-token").Get(ctx, repo, 1)
+		Expect(err).To(BeAssignableToTypeOf(&github.RateLimitError{}))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should honour Retry-After of secondary rate limits", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		factory := github.NewRESTClientFactory(server.URL, server.Client())
+
+		_, err := issueClient(factory, "busy-token").Get(ctx, repo, 1)
+		retryAfter, ok := github.RetryAfter(err)
+		Expect(ok).To(BeTrue())
+		Expect(retryAfter).To(BeNumerically("~", time.Minute, 5*time.Second))
+	})
+
+	It("should keep a separate token bucket per credential", func() {
+		factory := github.NewRESTClientFactory(server.URL, server.Client()).WithRateLimit(60, 1)
+
+		_, err := issueClient(factory, "first-token").Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = issueClient(factory, "first-token").Get(ctx, repo, 1)
+		retryAfter, ok := github.RetryAfter(err)
+		Expect(ok).To(BeTrue())
+		Expect(retryAfter).To(BeNumerically(">", 50*time.Second))
+
+		_, err = issueClient(factory, "second-token").Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("should expose the remaining quota as a gauge", func() {
+		factory := github.NewRESTClientFactory(server.URL, server.Client())
+
+		_, err := issueClient(factory, "metrics-token").Get(ctx, repo, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		families, err := metrics.Registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		var values []float64
+		for _, family := range families {
+			if family.GetName() == "githubissue_github_rate_limit_remaining" {
+				for _, metric := range family.GetMetric() {
+					values = append(values, metric.GetGauge().GetValue())
+				}
+			}
+		}
+		Expect(values).To(ContainElement(4999.0))
+	})
+})
@@ -0,0 +1,527 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the base URL of the public GitHub REST API.
+const DefaultBaseURL = "https://api.github.com"
+
+// listPageSize is the number of issues requested per page when listing.
+const listPageSize = 100
+
+// RESTClient implements IssueClient on top of the GitHub REST API.
+type RESTClient struct {
+	endpoints  Endpoints
+	token      string
+	httpClient *http.Client
+	// limit is the request budget of the credential, nil for unlimited.
+	limit *rateLimit
+	// cache stores GET responses for conditional requests, nil to disable.
+	cache *responseCache
+	// credential identifies the credential in cache keys without revealing it.
+	credential string
+}
+
+var _ IssueClient = &RESTClient{}
+
+// NewRESTClient returns a client for the REST API at baseURL authenticated
+// with token. An empty baseURL selects DefaultBaseURL, an empty token sends
+// anonymous requests and a nil httpClient uses a client with the timeouts of
+// NewHTTPClient.
+func NewRESTClient(baseURL, token string, httpClient *http.Client) *RESTClient {
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	return &RESTClient{
+		endpoints:  EndpointsFor(baseURL),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// Endpoints returns the endpoints of the GitHub instance the client talks to.
+func (c *RESTClient) Endpoints() Endpoints {
+	return c.endpoints
+}
+
+// RESTClientFactory creates RESTClients for a default REST API, which
+// credentials may override. Installation tokens of GitHub Apps, HTTP clients
+// for custom CA bundles and the request budget of each credential are shared
+// between all clients it creates.
+type RESTClientFactory struct {
+	baseURL         string
+	httpClient      *http.Client
+	appTokens       *AppTokenCache
+	requestsPerHour int
+	burst           int
+	cache           *responseCache
+
+	mu         sync.Mutex
+	caClients  map[[sha256.Size]byte]*http.Client
+	rateLimits map[string]*rateLimit
+}
+
+var _ ClientFactory = &RESTClientFactory{}
+
+// NewRESTClientFactory returns a factory for the REST API at baseURL. An empty
+// baseURL selects DefaultBaseURL and a nil httpClient uses a client with the
+// timeouts of NewHTTPClient.
+func NewRESTClientFactory(baseURL string, httpClient *http.Client) *RESTClientFactory {
+	return &RESTClientFactory{
+		baseURL:         baseURL,
+		httpClient:      httpClient,
+		appTokens:       NewAppTokenCache(),
+		requestsPerHour: DefaultRequestsPerHour,
+		burst:           DefaultBurst,
+		cache:           newResponseCache(DefaultCacheSize),
+		caClients:       map[[sha256.Size]byte]*http.Client{},
+		rateLimits:      map[string]*rateLimit{},
+	}
+}
+
+// WithRateLimit sets the budget of requests each credential may send per
+// hour, and how many of them may be sent at once. A non-positive
+// requestsPerHour only enforces the limits reported by GitHub. It must be
+// called before the factory is used.
+func (f *RESTClientFactory) WithRateLimit(requestsPerHour, burst int) *RESTClientFactory {
+	f.requestsPerHour = requestsPerHour
+	f.burst = burst
+	return f
+}
+
+// WithCacheSize sets how many bytes of GET responses are kept to revalidate
+// them with conditional requests, which GitHub does not count against the
+// rate limit. Zero disables the cache. It must be called before the factory
+// is used.
+func (f *RESTClientFactory) WithCacheSize(maxBytes int) *RESTClientFactory {
+	f.cache = nil
+	if maxBytes > 0 {
+		f.cache = newResponseCache(maxBytes)
+	}
+	return f
+}
+
+// IssueClient returns a RESTClient authenticated with creds, talking to the
+// base URL of creds if set. App credentials are exchanged for an installation
+// token first.
+func (f *RESTClientFactory) IssueClient(ctx context.Context, creds Credentials) (IssueClient, error) {
+	baseURL := f.baseURL
+	if creds.BaseURL != "" {
+		baseURL = creds.BaseURL
+	}
+	httpClient, err := f.httpClientFor(creds.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	token := creds.Token
+	if creds.IsApp() {
+		token, err = f.appTokens.Token(ctx, NewRESTClient(baseURL, "", httpClient), creds)
+		if err != nil {
+			return nil, err
+		}
+	}
+	client := NewRESTClient(baseURL, token, httpClient)
+	client.credential = credentialLabel(creds)
+	client.limit = f.rateLimitFor(client.endpoints.API, client.credential)
+	client.cache = f.cache
+	return client, nil
+}
+
+// rateLimitFor returns the request budget of a credential at the API at
+// baseURL, creating it on first use.
+func (f *RESTClientFactory) rateLimitFor(baseURL, credential string) *rateLimit {
+	key := baseURL + " " + credential
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	limit, ok := f.rateLimits[key]
+	if !ok {
+		limit = newRateLimit(credential, f.requestsPerHour, f.burst)
+		f.rateLimits[key] = limit
+	}
+	return limit
+}
+
+// httpClientFor returns the HTTP client trusting caBundle, creating it on
+// first use. An empty caBundle selects the client of the factory.
+func (f *RESTClientFactory) httpClientFor(caBundle []byte) (*http.Client, error) {
+	if len(caBundle) == 0 {
+		return f.httpClient, nil
+	}
+
+	key := sha256.Sum256(caBundle)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if httpClient, ok := f.caClients[key]; ok {
+		return httpClient, nil
+	}
+	httpClient, err := NewHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	f.caClients[key] = httpClient
+	return httpClient, nil
+}
+
+// Get returns a single issue.
+func (c *RESTClient) Get(ctx context.Context, repo string, number int) (*Issue, error) {
+	issue := &Issue{}
+	if err := c.do(ctx, http.MethodGet, issuePath(repo, number), nil, issue); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// List pages through all issues of the repository, excluding pull requests.
+func (c *RESTClient) List(ctx context.Context, repo string, opts ListOptions) ([]Issue, error) {
+	state := opts.State
+	if state == "" {
+		state = "all"
+	}
+
+	query := url.Values{"state": {state}, "per_page": {strconv.Itoa(listPageSize)}}
+	if !opts.CreatedSince.IsZero() {
+		// Issues created since then were also updated since then. Listed
+		// newest first, paging stops at the first issue created before.
+		query.Set("since", opts.CreatedSince.UTC().Format(time.RFC3339))
+		query.Set("sort", "created")
+		query.Set("direction", "desc")
+	}
+
+	var all []Issue
+	for page := 1; ; page++ {
+		var issues []Issue
+		query.Set("page", strconv.Itoa(page))
+		path := fmt.Sprintf("/repos/%s/issues?%s", repo, query.Encode())
+		if err := c.do(ctx, http.MethodGet, path, nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if !opts.CreatedSince.IsZero() && issue.CreatedAt.Before(opts.CreatedSince) {
+				return all, nil
+			}
+			if !issue.IsPullRequest() {
+				all = append(all, issue)
+			}
+		}
+		if len(issues) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// Create opens a new issue.
+func (c *RESTClient) Create(ctx context.Context, repo string, req IssueRequest) (*Issue, error) {
+	issue := &Issue{}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues", repo), req, issue); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// Update edits the fields set in req.
+func (c *RESTClient) Update(ctx context.Context, repo string, number int, req IssueRequest) (*Issue, error) {
+	issue := &Issue{}
+	if err := c.do(ctx, http.MethodPatch, issuePath(repo, number), req, issue); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// Close closes the issue.
+func (c *RESTClient) Close(ctx context.Context, repo string, number int) (*Issue, error) {
+	state := StateClosed
+	return c.Update(ctx, repo, number, IssueRequest{State: &state})
+}
+
+// Lock locks the conversation of the issue.
+func (c *RESTClient) Lock(ctx context.Context, repo string, number int) error {
+	return c.do(ctx, http.MethodPut, issuePath(repo, number)+"/lock", nil, nil)
+}
+
+// Comment adds a comment to the issue.
+func (c *RESTClient) Comment(ctx context.Context, repo string, number int, body string) (*Comment, error) {
+	comment := &Comment{}
+	request := map[string]string{"body": body}
+	if err := c.do(ctx, http.MethodPost, issuePath(repo, number)+"/comments", request, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// ListComments pages through all comments on the issue.
+func (c *RESTClient) ListComments(ctx context.Context, repo string, number int) ([]Comment, error) {
+	var all []Comment
+	for page := 1; ; page++ {
+		var comments []Comment
+		path := fmt.Sprintf("%s/comments?per_page=%d&page=%d", issuePath(repo, number), listPageSize, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &comments); err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if len(comments) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// GetComment returns a single comment.
+func (c *RESTClient) GetComment(ctx context.Context, repo string, id int64) (*Comment, error) {
+	comment := &Comment{}
+	if err := c.do(ctx, http.MethodGet, commentPath(repo, id), nil, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// UpdateComment replaces the body of the comment.
+func (c *RESTClient) UpdateComment(ctx context.Context, repo string, id int64, body string) (*Comment, error) {
+	comment := &Comment{}
+	request := map[string]string{"body": body}
+	if err := c.do(ctx, http.MethodPatch, commentPath(repo, id), request, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment deletes the comment.
+func (c *RESTClient) DeleteComment(ctx context.Context, repo string, id int64) error {
+	return c.do(ctx, http.MethodDelete, commentPath(repo, id), nil, nil)
+}
+
+// AddLabels adds labels to the issue, keeping the ones already set.
+func (c *RESTClient) AddLabels(ctx context.Context, repo string, number int, labels []string) error {
+	request := map[string][]string{"labels": labels}
+	return c.do(ctx, http.MethodPost, issuePath(repo, number)+"/labels", request, nil)
+}
+
+// ListLabels pages through all labels of the repository.
+func (c *RESTClient) ListLabels(ctx context.Context, repo string) ([]Label, error) {
+	var all []Label
+	for page := 1; ; page++ {
+		var labels []Label
+		path := fmt.Sprintf("/repos/%s/labels?per_page=%d&page=%d", repo, listPageSize, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &labels); err != nil {
+			return nil, err
+		}
+		all = append(all, labels...)
+		if len(labels) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// GetLabel returns the label with the given name.
+func (c *RESTClient) GetLabel(ctx context.Context, repo, name string) (*Label, error) {
+	label := &Label{}
+	if err := c.do(ctx, http.MethodGet, labelPath(repo, name), nil, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// CreateLabel adds a label to the repository.
+func (c *RESTClient) CreateLabel(ctx context.Context, repo string, label Label) (*Label, error) {
+	created := &Label{}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/labels", repo), label, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateLabel replaces the name, color and description of the named label.
+// The description is always sent so that clearing it takes effect.
+func (c *RESTClient) UpdateLabel(ctx context.Context, repo, name string, label Label) (*Label, error) {
+	updated := &Label{}
+	request := map[string]string{"new_name": label.Name, "color": label.Color, "description": label.Description}
+	if err := c.do(ctx, http.MethodPatch, labelPath(repo, name), request, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteLabel deletes the label from the repository and from all issues.
+func (c *RESTClient) DeleteLabel(ctx context.Context, repo, name string) error {
+	return c.do(ctx, http.MethodDelete, labelPath(repo, name), nil, nil)
+}
+
+// ListMilestones pages through all open and closed milestones of the repository.
+func (c *RESTClient) ListMilestones(ctx context.Context, repo string) ([]Milestone, error) {
+	var all []Milestone
+	for page := 1; ; page++ {
+		var milestones []Milestone
+		path := fmt.Sprintf("/repos/%s/milestones?state=all&per_page=%d&page=%d", repo, listPageSize, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &milestones); err != nil {
+			return nil, err
+		}
+		all = append(all, milestones...)
+		if len(milestones) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// GetMilestone returns a single milestone.
+func (c *RESTClient) GetMilestone(ctx context.Context, repo string, number int) (*Milestone, error) {
+	milestone := &Milestone{}
+	if err := c.do(ctx, http.MethodGet, milestonePath(repo, number), nil, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// CreateMilestone adds a milestone to the repository.
+func (c *RESTClient) CreateMilestone(ctx context.Context, repo string, req MilestoneRequest) (*Milestone, error) {
+	milestone := &Milestone{}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/milestones", repo), req, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// UpdateMilestone replaces the title, state, description and due date of the milestone.
+func (c *RESTClient) UpdateMilestone(ctx context.Context, repo string, number int,
+	req MilestoneRequest) (*Milestone, error) {
+	milestone := &Milestone{}
+	if err := c.do(ctx, http.MethodPatch, milestonePath(repo, number), req, milestone); err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// DeleteMilestone deletes the milestone, removing it from its issues.
+func (c *RESTClient) DeleteMilestone(ctx context.Context, repo string, number int) error {
+	return c.do(ctx, http.MethodDelete, milestonePath(repo, number), nil, nil)
+}
+
+func issuePath(repo string, number int) string {
+	return fmt.Sprintf("/repos/%s/issues/%d", repo, number)
+}
+
+func commentPath(repo string, id int64) string {
+	return fmt.Sprintf("/repos/%s/issues/comments/%d", repo, id)
+}
+
+func labelPath(repo, name string) string {
+	return fmt.Sprintf("/repos/%s/labels/%s", repo, url.PathEscape(name))
+}
+
+func milestonePath(repo string, number int) string {
+	return fmt.Sprintf("/repos/%s/milestones/%d", repo, number)
+}
+
+// do sends a request to the REST API and decodes the JSON response into out.
+// Requests beyond the rate limits of the credential fail with a RateLimitError.
+// GET requests are revalidated against the cache when it holds the response.
+func (c *RESTClient) do(ctx context.Context, method, path string, in, out any) error {
+	if err := c.limit.wait(ctx); err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoints.API+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	cacheKey := ""
+	var cached *cachedResponse
+	if method == http.MethodGet && c.cache != nil {
+		cacheKey = c.credential + " " + req.URL.String()
+		if cached = c.cache.get(cacheKey); cached != nil {
+			if cached.etag != "" {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
+			if cached.lastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.lastModified)
+			}
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if err := c.limit.observe(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Method:     method,
+			Path:       path,
+			Message:    string(bytes.TrimSpace(message)),
+		}
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cacheRequests.WithLabelValues("hit").Inc()
+		return decode(cached.body, out)
+	}
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if cacheKey != "" {
+		cacheRequests.WithLabelValues("miss").Inc()
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			c.cache.add(&cachedResponse{key: cacheKey, etag: etag, lastModified: lastModified, body: payload})
+		}
+	}
+	return decode(payload, out)
+}
+
+// decode unmarshals a JSON response body into out unless out is nil.
+func decode(payload []byte, out any) error {
+	if out == nil || len(payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(payload, out)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TalDebi/GithubIssue.git/internal/github"
+)
+
+var _ = Describe("RESTClient", func() {
+	const repo = "TalDebi/GithubIssue"
+	const pageSize = 100
+
+	var (
+		ctx     context.Context
+		mux     *http.ServeMux
+		server  *httptest.Server
+		client  *github.RESTClient
+		headers http.Header
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mux = http.NewServeMux()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			headers = req.Header.Clone()
+			mux.ServeHTTP(w, req)
+		}))
+		client = github.NewRESTClient(server.URL+"/", "secret-token", server.Client())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should create an issue with the fields set in the request", func() {
+		mux.HandleFunc("POST /repos/TalDebi/GithubIssue/issues", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]any
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(HaveKeyWithValue("title", "Broken build"))
+			Expect(body).To(HaveKeyWithValue("labels", ConsistOf("bug")))
+			Expect(body).NotTo(HaveKey("assignees"))
+			Expect(body).NotTo(HaveKey("state"))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"number": 7, "title": "Broken build", "state": "open", "html_url": "u", "node_id": "n"}`)
+		})
+
+		title := "Broken build"
+		issue, err := client.Create(ctx, repo, github.IssueRequest{Title: &title, Labels: &[]string{"bug"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issue.Number).To(Equal(7))
+		Expect(issue.HTMLURL).To(Equal("u"))
+		Expect(headers.Get("Authorization")).To(Equal("Bearer secret-token"))
+		Expect(headers.Get("Accept")).To(Equal("application/vnd.github+json"))
+	})
+
+	It("should page through issues and skip pull requests", func() {
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Query().Get("state")).To(Equal("all"))
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			var issues []github.Issue
+			if page == 1 {
+				for i := 1; i <= pageSize; i++ {
+					issues = append(issues, github.Issue{Number: i})
+				}
+				issues[0].PullRequest = &github.PullRequest{URL: "pr"}
+			} else if page == 2 {
+				issues = append(issues, github.Issue{Number: pageSize + 1})
+			}
+			Expect(json.NewEncoder(w).Encode(issues)).To(Succeed())
+		})
+
+		issues, err := client.List(ctx, repo, github.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(HaveLen(pageSize))
+		Expect(issues[0].Number).To(Equal(2))
+		Expect(issues[len(issues)-1].Number).To(Equal(pageSize + 1))
+	})
+
+	It("should only page through the issues created since the given time", func() {
+		since := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues", func(w http.ResponseWriter, req *http.Request) {
+			query := req.URL.Query()
+			Expect(query.Get("since")).To(Equal("2025-03-14T09:00:00Z"))
+			Expect(query.Get("sort")).To(Equal("created"))
+			Expect(query.Get("direction")).To(Equal("desc"))
+			Expect(query.Get("page")).To(Equal("1"))
+			issues := make([]github.Issue, pageSize)
+			for i := range issues {
+				// Issues older than since were updated since, so GitHub lists them too.
+				issues[i] = github.Issue{Number: pageSize - i, CreatedAt: since.Add(time.Duration(2-i) * time.Hour)}
+			}
+			Expect(json.NewEncoder(w).Encode(issues)).To(Succeed())
+		})
+
+		issues, err := client.List(ctx, repo, github.ListOptions{CreatedSince: since})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(HaveLen(3))
+		Expect(issues[0].Number).To(Equal(pageSize))
+	})
+
+	It("should return a typed error for missing issues", func() {
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues/404", func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		})
+
+		_, err := client.Get(ctx, repo, 404)
+		Expect(err).To(HaveOccurred())
+		Expect(github.IsNotFound(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Not Found"))
+	})
+
+	It("should close, lock, comment on and label an issue", func() {
+		mux.HandleFunc("PATCH /repos/TalDebi/GithubIssue/issues/3", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]any
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(Equal(map[string]any{"state": "closed"}))
+			fmt.Fprint(w, `{"number": 3, "state": "closed"}`)
+		})
+		mux.HandleFunc("PUT /repos/TalDebi/GithubIssue/issues/3/lock", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		mux.HandleFunc("POST /repos/TalDebi/GithubIssue/issues/3/comments", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]string
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": 11, "body": %q}`, body["body"])
+		})
+		mux.HandleFunc("POST /repos/TalDebi/GithubIssue/issues/3/labels", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string][]string
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body["labels"]).To(ConsistOf("bug", "triage"))
+			fmt.Fprint(w, `[]`)
+		})
+
+		issue, err := client.Close(ctx, repo, 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issue.State).To(Equal(github.StateClosed))
+		Expect(client.Lock(ctx, repo, 3)).To(Succeed())
+		comment, err := client.Comment(ctx, repo, 3, "done")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comment.ID).To(BeEquivalentTo(11))
+		Expect(comment.Body).To(Equal("done"))
+		Expect(client.AddLabels(ctx, repo, 3, []string{"bug", "triage"})).To(Succeed())
+	})
+
+	It("should list, edit and delete comments", func() {
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues/3/comments", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Query().Get("page")).To(Equal("1"))
+			fmt.Fprint(w, `[{"id": 11, "body": "first"}, {"id": 12, "body": "second"}]`)
+		})
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/issues/comments/12", func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"id": 12, "body": "second"}`)
+		})
+		mux.HandleFunc("PATCH /repos/TalDebi/GithubIssue/issues/comments/12", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]string
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			fmt.Fprintf(w, `{"id": 12, "body": %q}`, body["body"])
+		})
+		mux.HandleFunc("DELETE /repos/TalDebi/GithubIssue/issues/comments/12", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		comments, err := client.ListComments(ctx, repo, 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(2))
+		comment, err := client.GetComment(ctx, repo, 12)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comment.Body).To(Equal("second"))
+		comment, err = client.UpdateComment(ctx, repo, 12, "edited")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comment.Body).To(Equal("edited"))
+		Expect(client.DeleteComment(ctx, repo, 12)).To(Succeed())
+	})
+
+	It("should manage the labels of a repository", func() {
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/labels", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Query().Get("page")).To(Equal("1"))
+			fmt.Fprint(w, `[{"name": "bug", "color": "d73a4a"}, {"name": "good first issue", "color": "7057ff"}]`)
+		})
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/labels/{name}", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.PathValue("name")).To(Equal("good first issue"))
+			fmt.Fprint(w, `{"name": "good first issue", "color": "7057ff"}`)
+		})
+		mux.HandleFunc("POST /repos/TalDebi/GithubIssue/labels", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]string
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(Equal(map[string]string{"name": "triage", "color": "ededed"}))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"name": "triage", "color": "ededed"}`)
+		})
+		mux.HandleFunc("PATCH /repos/TalDebi/GithubIssue/labels/{name}", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.PathValue("name")).To(Equal("triage"))
+			var body map[string]string
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(Equal(map[string]string{"new_name": "needs triage", "color": "fbca04", "description": ""}))
+			fmt.Fprint(w, `{"name": "needs triage", "color": "fbca04"}`)
+		})
+		mux.HandleFunc("DELETE /repos/TalDebi/GithubIssue/labels/{name}", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		labels, err := client.ListLabels(ctx, repo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(HaveLen(2))
+		label, err := client.GetLabel(ctx, repo, "good first issue")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(label.Color).To(Equal("7057ff"))
+		label, err = client.CreateLabel(ctx, repo, github.Label{Name: "triage", Color: "ededed"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(label.Name).To(Equal("triage"))
+		label, err = client.UpdateLabel(ctx, repo, "triage", github.Label{Name: "needs triage", Color: "fbca04"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(label.Name).To(Equal("needs triage"))
+		Expect(client.DeleteLabel(ctx, repo, "needs triage")).To(Succeed())
+	})
+
+	It("should manage the milestones of a repository", func() {
+		dueOn := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/milestones", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Query().Get("state")).To(Equal("all"))
+			fmt.Fprint(w, `[{"number": 1, "title": "v1.0", "state": "closed"}, {"number": 2, "title": "v1.1"}]`)
+		})
+		mux.HandleFunc("GET /repos/TalDebi/GithubIssue/milestones/2", func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `{"number": 2, "title": "v1.1", "state": "open", "due_on": "2025-03-31T07:00:00Z"}`)
+		})
+		mux.HandleFunc("POST /repos/TalDebi/GithubIssue/milestones", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]any
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(Equal(map[string]any{
+				"title": "v1.2", "description": "", "due_on": "2025-03-31T00:00:00Z",
+			}))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"number": 3, "title": "v1.2", "state": "open"}`)
+		})
+		mux.HandleFunc("PATCH /repos/TalDebi/GithubIssue/milestones/3", func(w http.ResponseWriter, req *http.Request) {
+			var body map[string]any
+			Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+			Expect(body).To(Equal(map[string]any{"title": "v1.2", "state": "closed", "description": "", "due_on": nil}))
+			fmt.Fprint(w, `{"number": 3, "title": "v1.2", "state": "closed"}`)
+		})
+		mux.HandleFunc("DELETE /repos/TalDebi/GithubIssue/milestones/3", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		milestones, err := client.ListMilestones(ctx, repo)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(milestones).To(HaveLen(2))
+		milestone, err := client.GetMilestone(ctx, repo, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(milestone.DueOn).NotTo(BeNil())
+		milestone, err = client.CreateMilestone(ctx, repo, github.MilestoneRequest{Title: "v1.2", DueOn: &dueOn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(milestone.Number).To(Equal(3))
+		milestone, err = client.UpdateMilestone(ctx, repo, 3, github.MilestoneRequest{Title: "v1.2", State: "closed"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(milestone.State).To(Equal("closed"))
+		Expect(client.DeleteMilestone(ctx, repo, 3)).To(Succeed())
+	})
+})
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuetemplate renders issue bodies from Go text/templates.
+//
+// Templates get a curated set of functions modelled on sprig. The set is
+// deterministic on purpose: a body rendered twice from the same input is the
+// same, so templated issues do not drift on every resync. Functions reading
+// the clock or the environment of the operator are left out.
+package issuetemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// MaxBodyLength is the longest issue body GitHub accepts, in characters.
+const MaxBodyLength = 65536
+
+// Data is the input of a body template.
+type Data struct {
+	// Name and Namespace identify the GithubIssue.
+	Name      string
+	Namespace string
+	// Title is the title of the issue.
+	Title string
+	// Labels are the labels requested for the issue.
+	Labels []string
+	// Values are the templateData key/values of the spec.
+	Values map[string]string
+}
+
+// ErrBodyTooLong is returned for templates rendering a body GitHub would reject.
+var ErrBodyTooLong = fmt.Errorf("rendered body exceeds %d characters", MaxBodyLength)
+
+// Parse parses a body template. Missing keys of maps render as the zero value.
+func Parse(text string) (*template.Template, error) {
+	return template.New("body").Option("missingkey=zero").Funcs(Funcs()).Parse(text)
+}
+
+// Render parses text and executes it with data.
+func Render(text string, data Data) (string, error) {
+	tmpl, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	out := &limitedBuffer{limit: 4 * MaxBodyLength}
+	if err := tmpl.Execute(out, data); err != nil {
+		if errors.Is(err, ErrBodyTooLong) {
+			return "", ErrBodyTooLong
+		}
+		return "", err
+	}
+	body := out.String()
+	if len([]rune(body)) > MaxBodyLength {
+		return "", ErrBodyTooLong
+	}
+	return body, nil
+}
+
+// limitedBuffer fails writes beyond limit bytes, so runaway templates stop early.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, ErrBodyTooLong
+	}
+	return b.Buffer.Write(p)
+}
+
+// Funcs returns the functions available to body templates. Arguments follow
+// the order of sprig, so that piped values come last.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"repeat":     repeat,
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+		"indent":     indent,
+		"nindent":    nindent,
+		"join":       func(sep string, values []string) string { return strings.Join(values, sep) },
+		"splitList":  func(sep, s string) []string { return strings.Split(s, sep) },
+		"list":       func(values ...any) []any { return values },
+		"default":    defaultValue,
+		"empty":      empty,
+		"coalesce":   coalesce,
+		"required":   required,
+		"toJson":     toJSON,
+	}
+}
+
+// title upper-cases the first letter of every word.
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		defer func() { prev = r }()
+		if unicode.IsSpace(prev) {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+// repeat repeats s count times, refusing results longer than a body may be.
+func repeat(count int, s string) (string, error) {
+	if count < 0 || count*len(s) > MaxBodyLength*4 {
+		return "", ErrBodyTooLong
+	}
+	return strings.Repeat(s, count), nil
+}
+
+// indent prefixes every line of s with spaces, refusing results longer than
+// a body may be.
+func indent(spaces int, s string) (string, error) {
+	lines := strings.Count(s, "\n") + 1
+	if spaces < 0 || spaces > MaxBodyLength*4 || spaces*lines+len(s) > MaxBodyLength*4 {
+		return "", ErrBodyTooLong
+	}
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad), nil
+}
+
+// nindent is indent preceded by a newline.
+func nindent(spaces int, s string) (string, error) {
+	indented, err := indent(spaces, s)
+	if err != nil {
+		return "", err
+	}
+	return "\n" + indented, nil
+}
+
+// defaultValue returns given unless it is empty, and fallback otherwise.
+func defaultValue(fallback, given any) any {
+	if empty(given) {
+		return fallback
+	}
+	return given
+}
+
+// coalesce returns the first value that is not empty, or nil.
+func coalesce(values ...any) any {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// required fails the template with message when value is empty.
+func required(message string, value any) (any, error) {
+	if empty(value) {
+		return nil, errors.New(message)
+	}
+	return value, nil
+}
+
+// empty reports whether value is nil or the zero value of its type. Empty
+// slices and maps are empty as well.
+func empty(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+func toJSON(value any) (string, error) {
+	out, err := json.Marshal(value)
+	return string(out), err
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuetemplate_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/TalDebi/GithubIssue.git/internal/issuetemplate"
+)
+
+var _ = Describe("Render", func() {
+	data := issuetemplate.Data{
+		Name:      "db-outage",
+		Namespace: "incidents",
+		Title:     "Database outage",
+		Labels:    []string{"incident", "sev1"},
+		Values:    map[string]string{"service": "orders", "impact": "checkout fails"},
+	}
+
+	It("should render the issue fields and template data", func() {
+		body, err := issuetemplate.Render(
+			"## {{ .Title }}\nService: {{ .Values.service | upper }}\nLabels: {{ join \", \" .Labels }}\n"+
+				"Source: {{ .Namespace }}/{{ .Name }}", data)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal(
+			"## Database outage\nService: ORDERS\nLabels: incident, sev1\nSource: incidents/db-outage"))
+	})
+
+	DescribeTable("should provide sprig-like functions",
+		func(text, expected string) {
+			Expect(issuetemplate.Render(text, data)).To(Equal(expected))
+		},
+		Entry("title", `{{ "root cause analysis" | title }}`, "Root Cause Analysis"),
+		Entry("trim and replace", `{{ "  a-b  " | trim | replace "-" "+" }}`, "a+b"),
+		Entry("prefixes", `{{ "v1.2" | trimPrefix "v" }} {{ hasSuffix ".2" "v1.2" }}`, "1.2 true"),
+		Entry("default for missing keys", `{{ .Values.owner | default "unassigned" }}`, "unassigned"),
+		Entry("coalesce", `{{ coalesce .Values.owner .Values.service }}`, "orders"),
+		Entry("nindent", `impact:{{ .Values.impact | nindent 2 }}`, "impact:\n  checkout fails"),
+		Entry("splitList", `{{ range splitList "," "a,b" }}[{{ . }}]{{ end }}`, "[a][b]"),
+		Entry("toJson", `{{ toJson .Labels }}`, `["incident","sev1"]`),
+		Entry("quote", `{{ quote .Values.service }}`, `"orders"`),
+	)
+
+	It("should fail when a required value is missing", func() {
+		_, err := issuetemplate.Render(`{{ required "owner is required" .Values.owner }}`, data)
+		Expect(err).To(MatchError(ContainSubstring("owner is required")))
+	})
+
+	It("should report syntax errors", func() {
+		_, err := issuetemplate.Render("{{ .Title ", data)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should refuse bodies longer than GitHub accepts",
+		func(text string) {
+			_, err := issuetemplate.Render(text,
+				issuetemplate.Data{Values: map[string]string{"many": strings.Repeat(",", 10)}})
+			Expect(err).To(MatchError(issuetemplate.ErrBodyTooLong))
+		},
+		Entry("repeated in a loop", `{{ range splitList "," .Values.many }}{{ repeat 60000 "x" }}{{ end }}`),
+		Entry("repeat", `{{ repeat 70000 "x" }}`),
+		Entry("indent with a huge pad", `{{ .Values.many | indent 100000000 }}`),
+		Entry("indent with a pad per line", `{{ repeat 10 "\n" | indent 30000 }}`),
+		Entry("nindent with a huge pad", `{{ "x" | nindent 9223372036854775807 }}`),
+		Entry("indent with a negative pad", `{{ "x" | indent -1 }}`),
+		Entry("nindent with a negative pad", `{{ "x" | nindent -1 }}`),
+	)
+})
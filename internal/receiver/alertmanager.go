@@ -0,0 +1,378 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package receiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+)
+
+// AlertmanagerPath is the path Alertmanager webhook notifications are delivered to.
+const AlertmanagerPath = "/alertmanager"
+
+const (
+	// alertmanagerVersion is the version of the webhook payload understood by the receiver.
+	alertmanagerVersion = "4"
+
+	alertStatusFiring = "firing"
+
+	// maxListedAlerts bounds the alerts listed in the body of an issue.
+	maxListedAlerts = 50
+	// maxTitleLength is the longest title a GithubIssue accepts.
+	maxTitleLength = 256
+)
+
+// Alertmanager handles Alertmanager webhook notifications. Each alert group
+// is routed to a repository by the AlertRoutes and kept as a GithubIssue in
+// the issue namespace of the route, named after the fingerprint of the group
+// key. The issue is opened while the group fires and closed once it is resolved;
+// notifications of groups no route matches are acknowledged and dropped.
+type Alertmanager struct {
+	// Client reads the AlertRoutes and writes the GithubIssues.
+	Client client.Client
+	// Token is the bearer token notifications must carry.
+	Token string
+	// Insecure accepts notifications without a bearer token when Token is
+	// empty. Without it, an empty Token rejects every notification.
+	Insecure bool
+}
+
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=alertroutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dana.io.dana.io,resources=githubissues,verbs=get;list;watch;create;update
+
+// alertGroup is the payload of an Alertmanager webhook notification.
+type alertGroup struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []alert           `json:"alerts"`
+}
+
+// alert is an alert of an alertGroup.
+type alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+func (h *Alertmanager) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := log.FromContext(ctx)
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(req.Header.Get("Authorization")) {
+		logger.Info("Rejected Alertmanager notification without the bearer token")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "unable to read payload", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var group alertGroup
+	if err := json.Unmarshal(payload, &group); err != nil || group.GroupKey == "" {
+		http.Error(w, "malformed payload", http.StatusBadRequest)
+		return
+	}
+	if group.Version != alertmanagerVersion {
+		http.Error(w, fmt.Sprintf("unsupported payload version %q", group.Version), http.StatusBadRequest)
+		return
+	}
+	logger = logger.WithValues("groupKey", group.GroupKey, "status", group.Status)
+
+	route, err := h.route(ctx, group.CommonLabels)
+	if err != nil {
+		logger.Error(err, "Failed to list AlertRoutes")
+		http.Error(w, "unable to list AlertRoutes", http.StatusInternalServerError)
+		return
+	}
+	if route == nil {
+		logger.V(1).Info("Dropped alert group no AlertRoute matches")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	key := types.NamespacedName{
+		Namespace: route.Spec.IssueNamespace,
+		Name:      "alert-" + groupFingerprint(group.GroupKey),
+	}
+	if err := h.sync(ctx, key, route, &group); err != nil {
+		// Alertmanager retries failed notifications.
+		logger.Error(err, "Failed to sync GithubIssue of alert group", "githubissue", key)
+		http.Error(w, "unable to sync GithubIssue", http.StatusInternalServerError)
+		return
+	}
+	logger.V(1).Info("Synced GithubIssue of alert group", "githubissue", key)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authorized reports whether the Authorization header carries the bearer token.
+func (h *Alertmanager) authorized(header string) bool {
+	if h.Token == "" {
+		return h.Insecure
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) == 1
+}
+
+// route returns the AlertRoute with the highest priority matching the labels,
+// or nil when none does. Routes with an invalid matcher are logged and skipped.
+func (h *Alertmanager) route(ctx context.Context, labels map[string]string) (*danaiov1alpha1.AlertRoute, error) {
+	routes := &danaiov1alpha1.AlertRouteList{}
+	if err := h.Client.List(ctx, routes); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(routes.Items, func(i, j int) bool {
+		a, b := &routes.Items[i], &routes.Items[j]
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority > b.Spec.Priority
+		}
+		return a.Name < b.Name
+	})
+
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		matched, err := matches(route.Spec.Matchers, labels)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Skipping AlertRoute with an invalid matcher",
+				"alertroute", client.ObjectKeyFromObject(route))
+			continue
+		}
+		if matched {
+			return route, nil
+		}
+	}
+	return nil, nil
+}
+
+// matches reports whether all matchers match the labels.
+func matches(matchers []danaiov1alpha1.AlertMatcher, labels map[string]string) (bool, error) {
+	for _, m := range matchers {
+		value := labels[m.Label]
+		var matched bool
+		switch m.Operator {
+		case danaiov1alpha1.MatchEqual, "":
+			matched = value == m.Value
+		case danaiov1alpha1.MatchNotEqual:
+			matched = value != m.Value
+		case danaiov1alpha1.MatchRegexp, danaiov1alpha1.MatchNotRegexp:
+			re, err := regexp.Compile("^(?:" + m.Value + ")$")
+			if err != nil {
+				return false, fmt.Errorf("matcher of label %q: %w", m.Label, err)
+			}
+			matched = re.MatchString(value) == (m.Operator == danaiov1alpha1.MatchRegexp)
+		default:
+			return false, fmt.Errorf("matcher of label %q has unknown operator %q", m.Label, m.Operator)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sync creates or updates the GithubIssue of the alert group. The repository
+// and credentials are taken from the route when the issue is created only, as
+// they cannot change once it exists; later notifications update its title,
+// body and state. Resolved groups without an issue are ignored.
+func (h *Alertmanager) sync(ctx context.Context, key types.NamespacedName, route *danaiov1alpha1.AlertRoute,
+	group *alertGroup) error {
+	retriable := func(err error) bool { return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) }
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		githubIssue := &danaiov1alpha1.GithubIssue{}
+		err := h.Client.Get(ctx, key, githubIssue)
+		if apierrors.IsNotFound(err) {
+			if group.Status != alertStatusFiring {
+				return nil
+			}
+			githubIssue = newAlertIssue(key, route, group)
+			log.FromContext(ctx).Info("Creating GithubIssue for alert group", "githubissue", key,
+				"alertroute", client.ObjectKeyFromObject(route))
+			return h.Client.Create(ctx, githubIssue)
+		}
+		if err != nil {
+			return err
+		}
+
+		title, body, state := alertTitle(group), alertBody(group), alertState(group)
+		if githubIssue.Spec.Title == title && githubIssue.Spec.Description == body && githubIssue.Spec.State == state {
+			return nil
+		}
+		githubIssue.Spec.Title = title
+		githubIssue.Spec.Description = body
+		githubIssue.Spec.State = state
+		return h.Client.Update(ctx, githubIssue)
+	})
+}
+
+// newAlertIssue returns the GithubIssue of the alert group routed by route.
+func newAlertIssue(key types.NamespacedName, route *danaiov1alpha1.AlertRoute,
+	group *alertGroup) *danaiov1alpha1.GithubIssue {
+	return &danaiov1alpha1.GithubIssue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    map[string]string{danaiov1alpha1.AlertFingerprintLabel: groupFingerprint(group.GroupKey)},
+		},
+		Spec: danaiov1alpha1.GithubIssueSpec{
+			Repo:           route.Spec.Repo,
+			RepositoryRef:  route.Spec.RepositoryRef.DeepCopy(),
+			TokenSecretRef: route.Spec.TokenSecretRef.DeepCopy(),
+			AppSecretRef:   route.Spec.AppSecretRef.DeepCopy(),
+			CredentialsRef: route.Spec.CredentialsRef.DeepCopy(),
+			Title:          alertTitle(group),
+			Description:    alertBody(group),
+			Labels:         slices.Clone(route.Spec.Labels),
+			Assignees:      slices.Clone(route.Spec.Assignees),
+			State:          alertState(group),
+		},
+	}
+}
+
+// groupFingerprint identifies an alert group by its group key. Notifications
+// of the same group sent by the replicas of an Alertmanager cluster share it.
+func groupFingerprint(groupKey string) string {
+	sum := sha256.Sum256([]byte(groupKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// alertState returns the issue state of the alert group.
+func alertState(group *alertGroup) danaiov1alpha1.IssueState {
+	if group.Status == alertStatusFiring {
+		return danaiov1alpha1.IssueStateOpen
+	}
+	return danaiov1alpha1.IssueStateClosed
+}
+
+// alertTitle returns the summary shared by the alerts of the group, or the
+// alert name with the group labels.
+func alertTitle(group *alertGroup) string {
+	title := strings.TrimSpace(group.CommonAnnotations["summary"])
+	if title == "" {
+		title = group.CommonLabels["alertname"]
+		if title == "" {
+			title = "Alert"
+		}
+		isName := func(name string) bool { return name == "alertname" }
+		if labels := formatLabels(group.GroupLabels, isName); labels != "" {
+			title += " " + labels
+		}
+	}
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		title = string([]rune(title)[:maxTitleLength])
+	}
+	return title
+}
+
+// alertBody describes the alert group in markdown. It is deterministic, so
+// that notifications repeating the group leave the issue untouched.
+func alertBody(group *alertGroup) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "**Status:** %s\n", group.Status)
+	if labels := formatLabels(group.CommonLabels, nil); labels != "" {
+		fmt.Fprintf(&body, "**Labels:** `%s`\n", labels)
+	}
+	if description := strings.TrimSpace(group.CommonAnnotations["description"]); description != "" {
+		body.WriteString("\n" + description + "\n")
+	}
+
+	body.WriteString("\n### Alerts\n\n")
+	isCommon := func(name string) bool {
+		_, ok := group.CommonLabels[name]
+		return ok
+	}
+	for i, a := range group.Alerts {
+		if i == maxListedAlerts {
+			fmt.Fprintf(&body, "- ... and %d more\n", len(group.Alerts)-maxListedAlerts)
+			break
+		}
+		fmt.Fprintf(&body, "- **%s** since %s", a.Status, a.StartsAt.UTC().Format(time.RFC3339))
+		if labels := formatLabels(a.Labels, isCommon); labels != "" {
+			fmt.Fprintf(&body, " `%s`", labels)
+		}
+		if a.GeneratorURL != "" {
+			fmt.Fprintf(&body, " ([source](%s))", a.GeneratorURL)
+		}
+		body.WriteString("\n")
+		if summary := a.Annotations["summary"]; summary != "" && summary != group.CommonAnnotations["summary"] {
+			fmt.Fprintf(&body, "  %s\n", strings.TrimSpace(summary))
+		}
+	}
+	if group.TruncatedAlerts > 0 {
+		fmt.Fprintf(&body, "\n%d more alerts were truncated by Alertmanager.\n", group.TruncatedAlerts)
+	}
+	if group.ExternalURL != "" {
+		fmt.Fprintf(&body, "\nSent by [Alertmanager](%s).\n", group.ExternalURL)
+	}
+	return body.String()
+}
+
+// formatLabels formats the labels as {name="value", ...} sorted by name,
+// leaving out those skip, if set, reports true for.
+func formatLabels(labels map[string]string, skip func(name string) bool) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		if skip == nil || !skip(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	slices.Sort(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
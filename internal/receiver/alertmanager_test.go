@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package receiver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/receiver"
+)
+
+var _ = Describe("Alertmanager receiver", func() {
+	const token = "alertmanager-token"
+
+	var (
+		server *httptest.Server
+		c      client.Client
+	)
+	ctx := context.Background()
+
+	readPayload := func(name string) map[string]any {
+		raw, err := os.ReadFile(filepath.Join("testdata", name))
+		Expect(err).NotTo(HaveOccurred())
+		var payload map[string]any
+		Expect(json.Unmarshal(raw, &payload)).To(Succeed())
+		return payload
+	}
+
+	postAs := func(bearer string, payload any) *http.Response {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost, server.URL+receiver.AlertmanagerPath, bytes.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		return resp
+	}
+
+	serve := func(handler *receiver.Alertmanager) {
+		if server != nil {
+			server.Close()
+		}
+		mux := http.NewServeMux()
+		mux.Handle(receiver.AlertmanagerPath, handler)
+		server = httptest.NewServer(mux)
+	}
+
+	post := func(payload any) *http.Response {
+		return postAs(token, payload)
+	}
+
+	githubIssues := func() []danaiov1alpha1.GithubIssue {
+		list := &danaiov1alpha1.GithubIssueList{}
+		Expect(c.List(ctx, list)).To(Succeed())
+		return list.Items
+	}
+
+	route := func(issueNamespace, name string, priority int32, matchers ...danaiov1alpha1.AlertMatcher) client.Object {
+		return &danaiov1alpha1.AlertRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: danaiov1alpha1.AlertRouteSpec{
+				Matchers:       matchers,
+				Priority:       priority,
+				IssueNamespace: issueNamespace,
+				Repo:           "octo/" + name,
+				Labels:         []string{"alert"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			route("ops", "catch-all", 0),
+			route("payments", "payments", 10,
+				danaiov1alpha1.AlertMatcher{Label: "service", Operator: danaiov1alpha1.MatchRegexp, Value: "checkout|cart"},
+				danaiov1alpha1.AlertMatcher{Label: "severity", Operator: danaiov1alpha1.MatchNotEqual, Value: "info"}),
+		).Build()
+
+		serve(&receiver.Alertmanager{Client: c, Token: token})
+	})
+
+	AfterEach(func() {
+		server.Close()
+		server = nil
+	})
+
+	It("should open a GithubIssue for a firing alert group in the issue namespace of its route", func() {
+		resp := post(readPayload("alertmanager_firing.json"))
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+
+		items := githubIssues()
+		Expect(items).To(HaveLen(1))
+		githubIssue := items[0]
+		Expect(githubIssue.Namespace).To(Equal("payments"))
+		Expect(githubIssue.Name).To(HavePrefix("alert-"))
+		Expect(githubIssue.Labels).To(HaveKey(danaiov1alpha1.AlertFingerprintLabel))
+		Expect(githubIssue.Spec.Repo).To(Equal("octo/payments"))
+		Expect(githubIssue.Spec.Labels).To(Equal([]string{"alert"}))
+		Expect(githubIssue.Spec.Title).To(Equal("Checkout error rate above 5%"))
+		Expect(githubIssue.Spec.State).To(Equal(danaiov1alpha1.IssueStateOpen))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("**Status:** firing"))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring(
+			"More than 5% of checkout requests failed in the last 10 minutes."))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring(
+			"- **firing** since 2025-03-14T09:26:53Z `{pod=\"checkout-7d9f8b6c5-2xk4p\"}`"))
+		Expect(githubIssue.Spec.Description).To(ContainSubstring("[Alertmanager](http://alertmanager.monitoring:9093)"))
+	})
+
+	It("should keep a single GithubIssue per alert group", func() {
+		payload := readPayload("alertmanager_firing.json")
+		Expect(post(payload).StatusCode).To(Equal(http.StatusAccepted))
+		first := githubIssues()[0]
+
+		By("receiving the same notification from another Alertmanager replica")
+		Expect(post(payload).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(githubIssues()[0].ResourceVersion).To(Equal(first.ResourceVersion))
+
+		By("receiving the group with an alert less")
+		payload["alerts"] = payload["alerts"].([]any)[:1]
+		Expect(post(payload).StatusCode).To(Equal(http.StatusAccepted))
+		items := githubIssues()
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Name).To(Equal(first.Name))
+		Expect(items[0].Spec.Description).NotTo(ContainSubstring("checkout-7d9f8b6c5-9mq7z"))
+	})
+
+	It("should close the GithubIssue once the alert group is resolved and reopen it when it fires again", func() {
+		Expect(post(readPayload("alertmanager_firing.json")).StatusCode).To(Equal(http.StatusAccepted))
+
+		Expect(post(readPayload("alertmanager_resolved.json")).StatusCode).To(Equal(http.StatusAccepted))
+		items := githubIssues()
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Spec.State).To(Equal(danaiov1alpha1.IssueStateClosed))
+		Expect(items[0].Spec.Description).To(ContainSubstring("**Status:** resolved"))
+
+		Expect(post(readPayload("alertmanager_firing.json")).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(githubIssues()[0].Spec.State).To(Equal(danaiov1alpha1.IssueStateOpen))
+	})
+
+	It("should not open GithubIssues for resolved alert groups it has not seen firing", func() {
+		Expect(post(readPayload("alertmanager_resolved.json")).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(githubIssues()).To(BeEmpty())
+	})
+
+	It("should route alert groups the preferred route does not match to the next one", func() {
+		payload := readPayload("alertmanager_firing.json")
+		payload["commonLabels"].(map[string]any)["severity"] = "info"
+
+		Expect(post(payload).StatusCode).To(Equal(http.StatusAccepted))
+
+		items := githubIssues()
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Namespace).To(Equal("ops"))
+		Expect(items[0].Spec.Repo).To(Equal("octo/catch-all"))
+	})
+
+	It("should acknowledge alert groups no route matches", func() {
+		Expect(c.Delete(ctx, route("ops", "catch-all", 0))).To(Succeed())
+		payload := readPayload("alertmanager_firing.json")
+		payload["commonLabels"].(map[string]any)["service"] = "search"
+
+		Expect(post(payload).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(githubIssues()).To(BeEmpty())
+	})
+
+	It("should reject notifications without the bearer token", func() {
+		payload := readPayload("alertmanager_firing.json")
+		Expect(postAs("", payload).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(postAs("another-token", payload).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(githubIssues()).To(BeEmpty())
+	})
+
+	It("should reject every notification when no token is configured", func() {
+		serve(&receiver.Alertmanager{Client: c})
+		payload := readPayload("alertmanager_firing.json")
+		Expect(postAs("", payload).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(post(payload).StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(githubIssues()).To(BeEmpty())
+	})
+
+	It("should accept notifications without a token only when insecure", func() {
+		serve(&receiver.Alertmanager{Client: c, Insecure: true})
+		Expect(postAs("", readPayload("alertmanager_firing.json")).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(githubIssues()).To(HaveLen(1))
+	})
+
+	It("should reject payloads of other versions and malformed payloads", func() {
+		payload := readPayload("alertmanager_firing.json")
+		payload["version"] = "3"
+		Expect(post(payload).StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(post(map[string]any{"version": "4"}).StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(githubIssues()).To(BeEmpty())
+	})
+
+	It("should only accept POST requests", func() {
+		resp, err := http.Get(server.URL + receiver.AlertmanagerPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
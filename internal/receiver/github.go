@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package receiver accepts webhook deliveries from outside the cluster and turns them into reconcile requests
+// or GithubIssues.
+package receiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+)
+
+const (
+	// signatureHeader carries the HMAC-SHA256 of the payload keyed with the webhook secret.
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+	eventHeader     = "X-GitHub-Event"
+
+	// maxPayloadSize is the largest payload GitHub delivers.
+	maxPayloadSize = 25 << 20
+)
+
+// GitHub handles GitHub webhook deliveries of issues and issue_comment events.
+// Every GithubIssue bound to the issue of a verified delivery is sent to Events,
+// which the GithubIssue controller consumes through a source.Channel.
+type GitHub struct {
+	// Client lists the GithubIssues bound to the delivered issue.
+	Client client.Reader
+	// Secret is the webhook secret the deliveries are signed with.
+	Secret []byte
+	// Events receives the GithubIssues to reconcile.
+	Events chan<- event.GenericEvent
+}
+
+// issuePayload holds the fields shared by the issues and issue_comment payloads.
+type issuePayload struct {
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Issue struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+}
+
+func (h *GitHub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := log.FromContext(req.Context()).WithValues("delivery", req.Header.Get("X-GitHub-Delivery"))
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "unable to read payload", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.verify(payload, req.Header.Get(signatureHeader)) {
+		logger.Info("Rejected GitHub delivery with an invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Header.Get(eventHeader) {
+	case "issues", "issue_comment":
+	case "ping":
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		// Deliveries of other events are acknowledged so GitHub does not report them as failed.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	var p issuePayload
+	if err := json.Unmarshal(payload, &p); err != nil || p.Repository.FullName == "" || p.Issue.Number == 0 {
+		http.Error(w, "malformed payload", http.StatusBadRequest)
+		return
+	}
+
+	issues := &danaiov1alpha1.GithubIssueList{}
+	if err := h.Client.List(req.Context(), issues); err != nil {
+		logger.Error(err, "Failed to list GithubIssues")
+		http.Error(w, "unable to list GithubIssues", http.StatusInternalServerError)
+		return
+	}
+	for i := range issues.Items {
+		gi := &issues.Items[i]
+		if gi.Status.Number != p.Issue.Number || !strings.EqualFold(gi.Status.Repo, p.Repository.FullName) {
+			continue
+		}
+		select {
+		case h.Events <- event.GenericEvent{Object: gi}:
+		case <-req.Context().Done():
+			http.Error(w, "request cancelled", http.StatusServiceUnavailable)
+			return
+		}
+		logger.V(1).Info("Enqueued GithubIssue for GitHub delivery", "githubissue", client.ObjectKeyFromObject(gi))
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verify reports whether signature is the HMAC-SHA256 of payload keyed with the webhook secret.
+func (h *GitHub) verify(payload []byte, signature string) bool {
+	if len(h.Secret) == 0 || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.Secret)
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
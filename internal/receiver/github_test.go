@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package receiver_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/receiver"
+)
+
+var _ = Describe("GitHub receiver", func() {
+	const secret = "webhook-secret"
+	const payload = `{"action":"edited","repository":{"full_name":"octo/Hello"},"issue":{"number":7}}`
+
+	var (
+		server *httptest.Server
+		events chan event.GenericEvent
+	)
+
+	issue := func(name, repo string, number int) *danaiov1alpha1.GithubIssue {
+		return &danaiov1alpha1.GithubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     danaiov1alpha1.GithubIssueStatus{Repo: repo, Number: number},
+		}
+	}
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	post := func(eventType, body, signature string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, server.URL+receiver.GitHubPath, bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-GitHub-Event", eventType)
+		if signature != "" {
+			req.Header.Set("X-Hub-Signature-256", signature)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		return resp
+	}
+
+	received := func() []client.ObjectKey {
+		var keys []client.ObjectKey
+		for {
+			select {
+			case e := <-events:
+				keys = append(keys, client.ObjectKeyFromObject(e.Object))
+			default:
+				return keys
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(danaiov1alpha1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			issue("bound", "octo/hello", 7),
+			issue("other-number", "octo/hello", 8),
+			issue("other-repo", "octo/world", 7),
+		).Build()
+
+		events = make(chan event.GenericEvent, 10)
+		mux := http.NewServeMux()
+		mux.Handle(receiver.GitHubPath, &receiver.GitHub{Client: c, Secret: []byte(secret), Events: events})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should enqueue the GithubIssues bound to the issue of a signed delivery", func() {
+		resp := post("issues", payload, sign(payload))
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(received()).To(ConsistOf(client.ObjectKey{Namespace: "default", Name: "bound"}))
+	})
+
+	It("should enqueue the GithubIssues of issue_comment deliveries", func() {
+		resp := post("issue_comment", payload, sign(payload))
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(received()).To(ConsistOf(client.ObjectKey{Namespace: "default", Name: "bound"}))
+	})
+
+	It("should reject deliveries without a signature", func() {
+		resp := post("issues", payload, "")
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should reject deliveries signed with another secret", func() {
+		mac := hmac.New(sha256.New, []byte("another-secret"))
+		mac.Write([]byte(payload))
+		resp := post("issues", payload, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should reject deliveries whose payload does not match the signature", func() {
+		tampered := `{"repository":{"full_name":"octo/world"},"issue":{"number":7}}`
+		resp := post("issues", tampered, sign(payload))
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should acknowledge other events without enqueueing", func() {
+		Expect(post("ping", `{"zen":"Keep it simple."}`, sign(`{"zen":"Keep it simple."}`)).StatusCode).
+			To(Equal(http.StatusNoContent))
+		Expect(post("push", payload, sign(payload)).StatusCode).To(Equal(http.StatusAccepted))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should reject malformed payloads", func() {
+		body := `{"repository":{"full_name":"octo/hello"}}`
+		resp := post("issues", body, sign(body))
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	"github.com/TalDebi/GithubIssue.git/internal/issuetemplate"
+)
+
+const (
+	// maxLabelLength is the longest label name GitHub accepts.
+	maxLabelLength = 50
+	// maxAssignees is the most assignees GitHub keeps on an issue.
+	maxAssignees = 10
+)
+
+// repoPattern matches repositories in "owner/name" form.
+var repoPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`)
+
+// nolint:unused
+// log is for logging in this package.
+var githubissuelog = logf.Log.WithName("githubissue-resource")
+
+// SetupGithubIssueWebhookWithManager registers the webhook for GithubIssue in the manager.
+// As GithubIssue converts to the v1beta1 hub, this also serves the conversion webhook.
+func SetupGithubIssueWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&danaiov1alpha1.GithubIssue{}).
+		WithValidator(&GithubIssueCustomValidator{}).
+		WithDefaulter(&GithubIssueCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-dana-io-dana-io-v1alpha1-githubissue,mutating=true,failurePolicy=fail,sideEffects=None,groups=dana.io.dana.io,resources=githubissues,verbs=create;update,versions=v1alpha1,name=mgithubissue-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// GithubIssueCustomDefaulter fills in the fields left out of minimal GithubIssue manifests.
+type GithubIssueCustomDefaulter struct {
+	// Client reads the namespace annotations the repository is defaulted from.
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &GithubIssueCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind GithubIssue.
+func (d *GithubIssueCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	githubissue, ok := obj.(*danaiov1alpha1.GithubIssue)
+	if !ok {
+		return fmt.Errorf("expected an GithubIssue object but got %T", obj)
+	}
+	githubissuelog.V(1).Info("Defaulting for GithubIssue", "name", githubissue.GetName())
+
+	// Leave terminating objects alone, so that removing the finalizer never changes the spec.
+	if githubissue.DeletionTimestamp != nil {
+		return nil
+	}
+
+	spec := &githubissue.Spec
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = danaiov1alpha1.DeletionPolicyClose
+	}
+	if spec.SyncPolicy == "" {
+		spec.SyncPolicy = danaiov1alpha1.SyncPolicyEnforce
+	}
+	if spec.Repo == "" && spec.RepositoryRef == nil {
+		repo, err := d.defaultRepo(ctx, githubissue)
+		if err != nil {
+			return err
+		}
+		spec.Repo = repo
+	}
+	spec.Labels = normalizeLabels(append(spec.Labels, danaiov1alpha1.ManagedIssueLabel))
+	return nil
+}
+
+// defaultRepo returns the repository annotated on the namespace of githubissue, if any.
+func (d *GithubIssueCustomDefaulter) defaultRepo(ctx context.Context, githubissue *danaiov1alpha1.GithubIssue) (string, error) {
+	namespace := githubissue.Namespace
+	if namespace == "" {
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		namespace = req.Namespace
+	}
+
+	ns := &corev1.Namespace{}
+	if err := d.Client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return "", fmt.Errorf("unable to read the default repository of namespace %q: %w", namespace, err)
+	}
+	return ns.Annotations[danaiov1alpha1.DefaultRepoAnnotation], nil
+}
+
+// normalizeLabels lowercases labels and drops the blank and duplicate ones.
+func normalizeLabels(labels []string) []string {
+	normalized := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label != "" && !slices.Contains(normalized, label) {
+			normalized = append(normalized, label)
+		}
+	}
+	return normalized
+}
+
+// +kubebuilder:webhook:path=/validate-dana-io-dana-io-v1alpha1-githubissue,mutating=false,failurePolicy=fail,sideEffects=None,groups=dana.io.dana.io,resources=githubissues,verbs=create;update,versions=v1alpha1,name=vgithubissue-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// GithubIssueCustomValidator rejects GithubIssues that GitHub would refuse,
+// and changes of the repository once the issue has been created.
+type GithubIssueCustomValidator struct{}
+
+var _ webhook.CustomValidator = &GithubIssueCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type GithubIssue.
+func (v *GithubIssueCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	githubissue, ok := obj.(*danaiov1alpha1.GithubIssue)
+	if !ok {
+		return nil, fmt.Errorf("expected a GithubIssue object but got %T", obj)
+	}
+	githubissuelog.V(1).Info("Validation for GithubIssue upon creation", "name", githubissue.GetName())
+
+	return nil, invalid(githubissue, validateSpec(&githubissue.Spec, field.NewPath("spec")))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type GithubIssue.
+func (v *GithubIssueCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	githubissue, ok := newObj.(*danaiov1alpha1.GithubIssue)
+	if !ok {
+		return nil, fmt.Errorf("expected a GithubIssue object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*danaiov1alpha1.GithubIssue)
+	if !ok {
+		return nil, fmt.Errorf("expected a GithubIssue object for the oldObj but got %T", oldObj)
+	}
+	githubissuelog.V(1).Info("Validation for GithubIssue upon update", "name", githubissue.GetName())
+
+	// Objects admitted before this webhook existed must still accept
+	// metadata updates, such as the removal of the finalizer.
+	if equality.Semantic.DeepEqual(old.Spec, githubissue.Spec) {
+		return nil, nil
+	}
+
+	specPath := field.NewPath("spec")
+	allErrs := validateSpec(&githubissue.Spec, specPath)
+	if old.Status.Number != 0 {
+		if githubissue.Spec.Repo != old.Spec.Repo {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("repo"),
+				"cannot change after the issue is created"))
+		}
+		if !equality.Semantic.DeepEqual(githubissue.Spec.RepositoryRef, old.Spec.RepositoryRef) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("repositoryRef"),
+				"cannot change after the issue is created"))
+		}
+	}
+	return nil, invalid(githubissue, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type GithubIssue.
+func (v *GithubIssueCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec checks the fields of spec GitHub would refuse when the issue is synced.
+func validateSpec(spec *danaiov1alpha1.GithubIssueSpec, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Repo != "" && !repoPattern.MatchString(spec.Repo) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("repo"), spec.Repo, "must be in owner/name form"))
+	}
+	if strings.TrimSpace(spec.Title) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("title"), "must not be blank"))
+	}
+	for i, label := range spec.Labels {
+		if utf8.RuneCountInString(label) > maxLabelLength {
+			allErrs = append(allErrs, field.TooLong(specPath.Child("labels").Index(i), label, maxLabelLength))
+		}
+	}
+	if len(spec.Assignees) > maxAssignees {
+		allErrs = append(allErrs, field.TooMany(specPath.Child("assignees"), len(spec.Assignees), maxAssignees))
+	}
+	if spec.BodyTemplate != nil && spec.BodyTemplate.Inline != "" {
+		if _, err := issuetemplate.Parse(spec.BodyTemplate.Inline); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("bodyTemplate", "inline"), spec.BodyTemplate.Inline,
+				err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// invalid returns the Invalid error for allErrs, or nil when there are none.
+func invalid(githubissue *danaiov1alpha1.GithubIssue, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(danaiov1alpha1.GroupVersion.WithKind("GithubIssue").GroupKind(),
+		githubissue.Name, allErrs)
+}
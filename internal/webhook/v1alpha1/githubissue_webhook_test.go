@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	danaiov1alpha1 "github.com/TalDebi/GithubIssue.git/api/v1alpha1"
+	danaiov1beta1 "github.com/TalDebi/GithubIssue.git/api/v1beta1"
+)
+
+var _ = Describe("GithubIssue Webhook", func() {
+	var (
+		obj       *danaiov1alpha1.GithubIssue
+		oldObj    *danaiov1alpha1.GithubIssue
+		validator GithubIssueCustomValidator
+		defaulter GithubIssueCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		obj = &danaiov1alpha1.GithubIssue{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-issue", Namespace: "default"},
+			Spec: danaiov1alpha1.GithubIssueSpec{
+				Repo:   "octo/hello",
+				Title:  "Test issue",
+				Labels: []string{"bug"},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = GithubIssueCustomValidator{}
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		defaulter = GithubIssueCustomDefaulter{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "team",
+				Annotations: map[string]string{danaiov1alpha1.DefaultRepoAnnotation: "octo/team"},
+			}},
+		).Build()}
+	})
+
+	Context("When creating GithubIssue under Defaulting Webhook", func() {
+		It("Should apply the default policies", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.DeletionPolicy).To(Equal(danaiov1alpha1.DeletionPolicyClose))
+			Expect(obj.Spec.SyncPolicy).To(Equal(danaiov1alpha1.SyncPolicyEnforce))
+		})
+
+		It("Should keep the policies that are set", func() {
+			obj.Spec.DeletionPolicy = danaiov1alpha1.DeletionPolicyOrphan
+			obj.Spec.SyncPolicy = danaiov1alpha1.SyncPolicyObserve
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.DeletionPolicy).To(Equal(danaiov1alpha1.DeletionPolicyOrphan))
+			Expect(obj.Spec.SyncPolicy).To(Equal(danaiov1alpha1.SyncPolicyObserve))
+		})
+
+		It("Should add the managed label and normalize the label casing", func() {
+			obj.Spec.Labels = []string{"Bug", "bug", " Good First Issue "}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Labels).To(Equal([]string{"bug", "good first issue", danaiov1alpha1.ManagedIssueLabel}))
+		})
+
+		It("Should fill the repo from the namespace annotation", func() {
+			obj.Namespace = "team"
+			obj.Spec.Repo = ""
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Repo).To(Equal("octo/team"))
+		})
+
+		It("Should not fill the repo of issues that set one or reference a GithubRepository", func() {
+			obj.Namespace = "team"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Repo).To(Equal("octo/hello"))
+
+			obj.Spec.Repo = ""
+			obj.Spec.RepositoryRef = &danaiov1alpha1.LocalRepositoryReference{Name: "hello"}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Repo).To(BeEmpty())
+		})
+
+		It("Should leave the repo empty in namespaces without the annotation", func() {
+			obj.Spec.Repo = ""
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Repo).To(BeEmpty())
+		})
+
+		It("Should not change terminating GithubIssues", func() {
+			obj.DeletionTimestamp = &metav1.Time{}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Labels).To(Equal([]string{"bug"}))
+		})
+	})
+
+	Context("When creating or updating GithubIssue under Validating Webhook", func() {
+		It("Should admit a valid GithubIssue", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
+		})
+
+		It("Should deny creation if the repo is not in owner/name form", func() {
+			obj.Spec.Repo = "octo/hello/world"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.repo")))
+		})
+
+		It("Should deny creation if the title is blank", func() {
+			obj.Spec.Title = "   "
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.title")))
+		})
+
+		It("Should deny creation if a label is longer than 50 characters", func() {
+			obj.Spec.Labels = []string{"bug", strings.Repeat("x", 51)}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.labels[1]")))
+		})
+
+		It("Should deny creation if there are more than 10 assignees", func() {
+			for i := range 11 {
+				obj.Spec.Assignees = append(obj.Spec.Assignees, strings.Repeat("a", i+1))
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.assignees")))
+		})
+
+		It("Should deny creation if the inline body template does not parse", func() {
+			obj.Spec.Description = ""
+			obj.Spec.BodyTemplate = &danaiov1alpha1.BodyTemplate{Inline: "{{ .Title "}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.bodyTemplate.inline")))
+		})
+
+		It("Should deny changing the repo once the issue is created", func() {
+			oldObj.Status.Number = 7
+			obj.Status.Number = 7
+			obj.Spec.Repo = "octo/world"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("cannot change after the issue is created")))
+		})
+
+		It("Should allow changing the repo before the issue is created", func() {
+			obj.Spec.Repo = "octo/world"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+
+		It("Should deny switching to a repositoryRef once the issue is created", func() {
+			oldObj.Status.Number = 7
+			obj.Spec.Repo = ""
+			obj.Spec.RepositoryRef = &danaiov1alpha1.LocalRepositoryReference{Name: "hello"}
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.repositoryRef")))
+		})
+
+		It("Should admit updates that leave an already invalid spec unchanged", func() {
+			oldObj.Spec.Labels = []string{strings.Repeat("x", 51)}
+			obj = oldObj.DeepCopy()
+			obj.Finalizers = nil
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
+		})
+	})
+
+	Context("When applying GithubIssues to the API server", func() {
+		It("Should reject invalid GithubIssues at apply time", func() {
+			obj.Name = "invalid-labels"
+			obj.Spec.Labels = []string{strings.Repeat("x", 51)}
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("spec.labels[0]")))
+		})
+
+		It("Should admit valid GithubIssues", func() {
+			obj.Name = "valid"
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+		})
+
+		It("Should default GithubIssues at apply time", func() {
+			obj.Name = "defaulted"
+			obj.Spec.Labels = []string{"Bug"}
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Labels).To(Equal([]string{"bug", danaiov1alpha1.ManagedIssueLabel}))
+			Expect(obj.Spec.DeletionPolicy).To(Equal(danaiov1alpha1.DeletionPolicyClose))
+			Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+		})
+
+		It("Should serve GithubIssues created as v1alpha1 as v1beta1", func() {
+			obj.Name = "converted"
+			obj.Spec.Description = "Converted"
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+
+			hub := &danaiov1beta1.GithubIssue{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), hub)).To(Succeed())
+			Expect(hub.Spec.Title).To(Equal(obj.Spec.Title))
+			Expect(hub.Spec.Description).To(Equal("Converted"))
+			Expect(hub.Spec.Labels).To(Equal(obj.Spec.Labels))
+			Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+		})
+	})
+})